@@ -0,0 +1,132 @@
+package solitaire
+
+import "math/bits"
+
+// helpers analysing a solution path (boards in playing order)
+
+// get the peg count of every board of a path
+func PegCountSeries(path []uint64) []int {
+	series := make([]int, len(path))
+	for i, board := range path {
+		series[i] = PegCount(board)
+	}
+	return series
+}
+
+// get the boards of a path in reverse order, e.g. a solution from the goal back to the start
+// (the order in which the search finds it)
+func Reversed(path []uint64) []uint64 {
+	reversed := make([]uint64, len(path))
+	for i, board := range path {
+		reversed[len(path)-1-i] = board
+	}
+	return reversed
+}
+
+// get the cells involved in any move of a path (moved, jumped over or filled)
+func UsedCells(path []uint64) uint64 {
+	var used uint64
+	for _, move := range SolutionMoves(path) {
+		used |= move.all
+	}
+	return used
+}
+
+// get the bounding box of the cells used by a path as minimum row, minimum column,
+// maximum row and maximum column; all four are -1 if the path has no moves
+func UsedBounds(path []uint64) (int, int, int, int) {
+	used := UsedCells(path)
+	if used == 0 {
+		return -1, -1, -1, -1
+	}
+	minRow, minCol, maxRow, maxCol := 6, 6, 0, 0
+	for cell := 0; cell < 49; cell++ {
+		if used&(1<<cell) == 0 {
+			continue
+		}
+		row, col := bitToCoord(cell)
+		minRow, maxRow = min(minRow, row), max(maxRow, row)
+		minCol, maxCol = min(minCol, col), max(maxCol, col)
+	}
+	return minRow, minCol, maxRow, maxCol
+}
+
+// get the moves of a path as grid coordinates: for every move the (row, col) pairs of the
+// moved peg, the jumped over peg and the destination (see bitToCoord)
+func SolutionCoords(path []uint64) [][3][2]int {
+	moves := SolutionMoves(path)
+	coords := make([][3][2]int, len(moves))
+	for i, move := range moves {
+		from, over, to := moveCells(move)
+		for j, cell := range [3]int{from, over, to} {
+			row, col := bitToCoord(cell)
+			coords[i][j] = [2]int{row, col}
+		}
+	}
+	return coords
+}
+
+// get the cell (bit index) of the last peg of a path, returns false if the last board of
+// the path does not hold exactly one peg
+func FinalPegCell(path []uint64) (int, bool) {
+	if len(path) == 0 {
+		return 0, false
+	}
+	board := path[len(path)-1]
+	if PegCount(board) != 1 {
+		return 0, false
+	}
+	return bits.TrailingZeros64(board), true
+}
+
+// count the forced moves of a path, i.e. the moves made on a board where no other move
+// was possible - the more of them a solution has, the easier it is to find
+func ForcedMoveCount(path []uint64) int {
+	forced := 0
+	for i := 0; i+1 < len(path); i++ {
+		if Mobility(path[i]) == 1 {
+			forced++
+		}
+	}
+	return forced
+}
+
+// get the pegs of a board that are part of no legal move, neither as the jumping nor as the
+// jumped over peg - they can not move right now, but may become movable after other moves
+func StuckPegs(board uint64) uint64 {
+	movable := uint64(0)
+	for _, move := range LegalMoves(board) {
+		movable |= move.before
+	}
+	return board &^ movable
+}
+
+// get the pegs of a board that can never again be part of a move, a subset of StuckPegs. A
+// move needs a peg in two cells and a hole in the third: starting with the pegs and holes
+// of the board, a cell may hold a peg later if a move that may ever be made ends in it, and
+// may be empty if such a move starts in it or jumps over it. The moves that may ever be
+// made are collected until nothing changes, the pegs in none of them are dead
+// Note: this overestimates the moves that can be made (it ignores when the cells are
+// filled and emptied), so a peg that is not dead may still never move again
+func DeadPegs(board uint64) uint64 {
+	mayHold, mayBeEmpty := board, Holes(board)
+	possible := make([]bool, len(defaultBoard.Moves))
+	for changed := true; changed; {
+		changed = false
+		for i, move := range defaultBoard.Moves {
+			if !possible[i] && move.before&mayHold == move.before && move.after&mayBeEmpty == move.after {
+				possible[i] = true
+				mayHold |= move.after
+				mayBeEmpty |= move.before
+				changed = true
+			}
+		}
+	}
+	alive := uint64(0)
+	for i, move := range defaultBoard.Moves {
+		if possible[i] {
+			alive |= move.all
+		}
+	}
+	return board &^ alive
+}
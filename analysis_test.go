@@ -0,0 +1,52 @@
+package solitaire
+
+import "testing"
+
+func TestSolutionCoords(t *testing.T) {
+	path, err := ReplayNotation(INITIAL_BOARD, englishSolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coords := SolutionCoords(path)
+	if len(coords) != 31 {
+		t.Fatalf("got %d moves, want 31", len(coords))
+	}
+	// b4-d4 jumps over c4
+	if want := [3][2]int{{3, 1}, {3, 2}, {3, 3}}; coords[0] != want {
+		t.Errorf("the first move is %v, want %v", coords[0], want)
+	}
+	for i, move := range coords {
+		from, over, to := move[0], move[1], move[2]
+		if over[0]-from[0] != to[0]-over[0] || over[1]-from[1] != to[1]-over[1] ||
+			abs(to[0]-from[0])+abs(to[1]-from[1]) != 2 {
+			t.Errorf("move %d %v is not a jump over the middle cell", i+1, move)
+		}
+	}
+}
+
+func TestForcedMoveCount(t *testing.T) {
+	cells := func(names string) uint64 {
+		board, err := parseCells(names)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return board
+	}
+	// a4-c4 is one of two moves (g4-e4 is the other), then g4-e4 is the only one left
+	path := []uint64{cells("a4,b4,f4,g4"), cells("c4,f4,g4"), cells("c4,e4")}
+	if err := VerifySolution(path[0], path[2], path); err != nil {
+		t.Fatal(err)
+	}
+	if forced := ForcedMoveCount(path); forced != 1 {
+		t.Errorf("ForcedMoveCount = %d, want 1", forced)
+	}
+	if forced := ForcedMoveCount(path[1:]); forced != 1 {
+		t.Errorf("ForcedMoveCount of the last move = %d, want 1", forced)
+	}
+	if forced := ForcedMoveCount(path[:2]); forced != 0 {
+		t.Errorf("ForcedMoveCount of the first move = %d, want 0", forced)
+	}
+	if forced := ForcedMoveCount(path[:1]); forced != 0 {
+		t.Errorf("ForcedMoveCount without moves = %d, want 0", forced)
+	}
+}
@@ -0,0 +1,191 @@
+package solitaire
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// the terminal playback of -animate: the boards of a solution are drawn one after the other
+// in place. If the input is a terminal, it is switched to reading single key presses (with
+// stty, so this needs a Unix terminal): space pauses and resumes, the right and left arrow
+// keys step forward and back (pausing the playback) and q quits. The terminal settings are
+// restored when the playback ends, also if it is interrupted by Ctrl-C
+
+// a key press controlling the playback
+type animationKey int
+
+const (
+	keyPause animationKey = iota
+	keyForward
+	keyBack
+	keyQuit
+)
+
+// get the keys of the playback in the input read from the terminal, other keys are ignored
+// (the arrow keys send the escape sequences ESC [ C and ESC [ D)
+func decodeKeys(input []byte) []animationKey {
+	var keys []animationKey
+	for i := 0; i < len(input); i++ {
+		switch input[i] {
+		case ' ':
+			keys = append(keys, keyPause)
+		case 'q', 'Q':
+			keys = append(keys, keyQuit)
+		case 0x1b:
+			if i+2 < len(input) && input[i+1] == '[' {
+				switch input[i+2] {
+				case 'C':
+					keys = append(keys, keyForward)
+				case 'D':
+					keys = append(keys, keyBack)
+				}
+				i += 2
+			}
+		}
+	}
+	return keys
+}
+
+// the state of the playback: the index of the board shown and whether it is paused
+type animation struct {
+	frame  int
+	frames int
+	paused bool
+}
+
+// show the next board unless the playback is paused, returns false if nothing changed
+func (a *animation) tick() bool {
+	if a.paused || a.frame == a.frames-1 {
+		return false
+	}
+	a.frame++
+	return true
+}
+
+// handle a key press, returns false if the playback has to end
+func (a *animation) handle(key animationKey) bool {
+	switch key {
+	case keyPause:
+		a.paused = !a.paused
+	case keyForward:
+		a.paused = true
+		a.frame = min(a.frame+1, a.frames-1)
+	case keyBack:
+		a.paused = true
+		a.frame = max(a.frame-1, 0)
+	case keyQuit:
+		return false
+	}
+	return true
+}
+
+// play the boards of a solution in the terminal, one every delay, until the last board is
+// shown (without keyboard controls) or q is pressed, or the context is canceled
+func Animate(ctx context.Context, path []uint64, delay time.Duration) {
+	if len(path) == 0 {
+		return
+	}
+	var keys <-chan animationKey
+	if restore, err := setCbreakMode(os.Stdin); err != nil {
+		fmt.Fprintln(os.Stderr, "no keyboard controls, the input is not a terminal")
+	} else {
+		defer restore()
+		keys = animationKeys(os.Stdin)
+	}
+	a := animation{frames: len(path)}
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for changed := true; ; {
+		if changed {
+			drawAnimationFrame(path, a, keys != nil)
+		}
+		// without controls the playback ends with the last board
+		if keys == nil && a.frame == a.frames-1 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed = a.tick()
+		case key := <-keys:
+			if !a.handle(key) {
+				return
+			}
+			changed = true
+		}
+	}
+}
+
+// clear the terminal and draw the board of the playback with the move leading to it
+func drawAnimationFrame(path []uint64, a animation, controls bool) {
+	fmt.Print("\033[H\033[2J")
+	prev := path[max(a.frame-1, 0)]
+	printBoard(path[a.frame], prev)
+	status := fmt.Sprintf("board %d of %d, %d pegs", a.frame+1, a.frames, PegCount(path[a.frame]))
+	if a.frame > 0 {
+		status += ", move " + MoveString(moveBetween(prev, path[a.frame]))
+	}
+	if a.paused {
+		status += " (paused)"
+	}
+	fmt.Println(status)
+	if controls {
+		fmt.Println("space: pause/resume, left/right: step back/forward, q: quit")
+	}
+}
+
+// the key presses read from the terminal, shared by all playbacks: the reading goroutine
+// can not be stopped while it waits for input, so there is only ever one
+var (
+	keysOnce   sync.Once
+	keysPushed chan animationKey
+)
+
+// get the key presses of the playback read from the input
+func animationKeys(in io.Reader) <-chan animationKey {
+	keysOnce.Do(func() {
+		keysPushed = make(chan animationKey)
+		go func() {
+			buffer := make([]byte, 16)
+			for {
+				n, err := in.Read(buffer)
+				for _, key := range decodeKeys(buffer[:n]) {
+					keysPushed <- key
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	})
+	return keysPushed
+}
+
+// switch the terminal to reading single key presses without echoing them, returns the
+// function restoring the previous settings. Ctrl-C still interrupts the program, since the
+// terminal keeps generating signals
+func setCbreakMode(tty *os.File) (func(), error) {
+	saved, err := stty(tty, "-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty(tty, "-icanon", "-echo", "min", "1"); err != nil {
+		return nil, err
+	}
+	return func() { stty(tty, strings.TrimSpace(saved)) }, nil
+}
+
+// run stty on the terminal, returns its output
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	out, err := cmd.Output()
+	return string(out), err
+}
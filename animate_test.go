@@ -0,0 +1,62 @@
+package solitaire
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDecodeKeys(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []animationKey
+	}{
+		{" ", []animationKey{keyPause}},
+		{"q", []animationKey{keyQuit}},
+		{"Q", []animationKey{keyQuit}},
+		{"\x1b[C\x1b[D", []animationKey{keyForward, keyBack}},
+		// the up and down arrow keys and other keys are ignored
+		{"x\x1b[A\x1b[B \x1b", []animationKey{keyPause}},
+		{"", nil},
+	}
+	for _, c := range cases {
+		if got := decodeKeys([]byte(c.input)); !slices.Equal(got, c.want) {
+			t.Errorf("decodeKeys(%q) = %v, want %v", c.input, got, c.want)
+		}
+	}
+}
+
+func TestAnimationSteps(t *testing.T) {
+	a := animation{frames: 3}
+	if !a.tick() || a.frame != 1 {
+		t.Fatalf("the first tick shows board %d", a.frame)
+	}
+	a.handle(keyPause)
+	if a.tick() || a.frame != 1 {
+		t.Errorf("a tick while paused shows board %d", a.frame)
+	}
+	a.handle(keyPause)
+	if !a.tick() || a.frame != 2 {
+		t.Errorf("a tick after resuming shows board %d", a.frame)
+	}
+	if a.tick() || a.frame != 2 {
+		t.Errorf("a tick on the last board shows board %d", a.frame)
+	}
+	// stepping pauses the playback and stops at the first and the last board
+	a.handle(keyForward)
+	if !a.paused || a.frame != 2 {
+		t.Errorf("a step forward from the last board shows board %d, paused %v", a.frame, a.paused)
+	}
+	for i := 0; i < 3; i++ {
+		a.handle(keyBack)
+	}
+	if a.frame != 0 {
+		t.Errorf("three steps back show board %d", a.frame)
+	}
+	a.handle(keyForward)
+	if a.frame != 1 || a.tick() {
+		t.Errorf("a step forward shows board %d, then the playback continues", a.frame)
+	}
+	if !a.handle(keyPause) || a.handle(keyQuit) {
+		t.Error("only q ends the playback")
+	}
+}
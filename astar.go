@@ -0,0 +1,95 @@
+package solitaire
+
+import (
+	"container/heap"
+	"context"
+)
+
+// estimate of the number of moves needed to reach the goal from a board, it must never be
+// larger than the real number of moves for SolveAStar to work
+type Heuristic func(board uint64, goal uint64) int
+
+// the default heuristic: every move removes one peg, so this is exact if the goal can be
+// reached at all (but says nothing about whether it can)
+func PegCountHeuristic(board uint64, goal uint64) int {
+	return PegCount(board) - PegCount(goal)
+}
+
+// find a solution by a best first search: the boards are expanded in the order of
+// f = g + h, the number of moves made so far plus the estimate of the heuristic (the peg
+// count heuristic if h is nil), boards reached with more moves come first for the same f
+// Note: with the peg count heuristic f is the same for every board, so this is a depth first
+// search that keeps all visited boards in memory - it only pays off with a heuristic that
+// detects boards from which the goal can not be reached
+func SolveAStar(ctx context.Context, start uint64, goal uint64, h Heuristic) ([]uint64, error) {
+	if h == nil {
+		h = PegCountHeuristic
+	}
+	if !IsSolvable(start, goal) {
+		return nil, ErrNoSolution
+	}
+	goalPegs := PegCount(goal)
+	// the board every visited board was reached from
+	parent := map[uint64]uint64{start: start}
+	open := &boardQueue{{board: start, f: h(start, goal)}}
+	for count := 1; open.Len() > 0; count++ {
+		if count%contextCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		node := heap.Pop(open).(queuedBoard)
+		if node.board == goal {
+			path := []uint64{goal}
+			for board := goal; board != start; {
+				board = parent[board]
+				path = append(path, board)
+			}
+			return Reversed(path), nil
+		}
+		if PegCount(node.board) <= goalPegs {
+			continue
+		}
+		for _, move := range defaultBoard.Moves {
+			next, ok := Apply(node.board, move)
+			if !ok {
+				continue
+			}
+			// every path to a board has the same length, so the first one is as good as any
+			if _, found := parent[next]; found {
+				continue
+			}
+			parent[next] = node.board
+			heap.Push(open, queuedBoard{board: next, g: node.g + 1, f: node.g + 1 + h(next, goal)})
+		}
+	}
+	return nil, ErrNoSolution
+}
+
+// a board waiting to be expanded by SolveAStar
+type queuedBoard struct {
+	board uint64
+	// moves made to reach the board and the estimated total number of moves
+	g, f int
+}
+
+// priority queue of the boards of SolveAStar (implements heap.Interface)
+type boardQueue []queuedBoard
+
+func (q boardQueue) Len() int { return len(q) }
+
+func (q boardQueue) Less(i, j int) bool {
+	if q[i].f != q[j].f {
+		return q[i].f < q[j].f
+	}
+	return q[i].g > q[j].g
+}
+
+func (q boardQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *boardQueue) Push(x any) { *q = append(*q, x.(queuedBoard)) }
+
+func (q *boardQueue) Pop() any {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
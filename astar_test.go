@@ -0,0 +1,28 @@
+package solitaire
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSolveAStar(t *testing.T) {
+	for _, moves := range []int{16, 21} {
+		start := boardAfterMoves(t, moves)
+		path, err := SolveAStar(context.Background(), start, GOAL_BOARD, nil)
+		if err != nil {
+			t.Fatalf("after %d moves: %v", moves, err)
+		}
+		if err := VerifySolution(start, GOAL_BOARD, path); err != nil {
+			t.Errorf("after %d moves: %v", moves, err)
+		}
+	}
+	// a single peg in d7 can not be reached from the board after 21 moves
+	if _, err := SolveAStar(context.Background(), boardAfterMoves(t, 21), 1<<coordToBit(6, 3), PegCountHeuristic); err != ErrNoSolution {
+		t.Errorf("got %v, want ErrNoSolution", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SolveAStar(ctx, INITIAL_BOARD, GOAL_BOARD, nil); err != context.Canceled {
+		t.Errorf("canceled: got %v, want context.Canceled", err)
+	}
+}
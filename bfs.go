@@ -0,0 +1,105 @@
+package solitaire
+
+// find all solutions of minimal length from the start to the goal board by a breadth
+// first search, at most "limit" solutions are returned (all of them if limit is 0)
+// Note: every jump removes exactly one peg, so with the standard moves all solutions have
+// the same length and this returns all solutions. For each layer the search keeps every
+// reachable board together with its predecessors, which needs a lot of memory for boards
+// with many pegs (the English board has millions of boards in its middle layers)
+func MinLengthSolutions(start uint64, goal uint64, limit int) [][]uint64 {
+	if !IsSolvable(start, goal) {
+		return nil
+	}
+	// layers[i] maps every board reachable in i moves to the boards of layer i-1 leading to it
+	layers := []map[uint64][]uint64{{start: nil}}
+	goalPegs := PegCount(goal)
+	for {
+		layer := layers[len(layers)-1]
+		if _, found := layer[goal]; found {
+			break
+		}
+		next := map[uint64][]uint64{}
+		for board := range layer {
+			// no further move can lead to the goal once we have as few pegs as the goal
+			if PegCount(board) <= goalPegs {
+				continue
+			}
+			for _, move := range defaultBoard.Moves {
+				if newBoard, ok := Apply(board, move); ok {
+					next[newBoard] = append(next[newBoard], board)
+				}
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		layers = append(layers, next)
+	}
+
+	// collect the paths by walking back from the goal through the predecessors
+	var solutions [][]uint64
+	path := make([]uint64, len(layers))
+	var collect func(depth int, board uint64) bool
+	collect = func(depth int, board uint64) bool {
+		path[depth] = board
+		if depth == 0 {
+			solutions = append(solutions, append([]uint64(nil), path...))
+			return limit == 0 || len(solutions) < limit
+		}
+		for _, prev := range layers[depth][board] {
+			if !collect(depth-1, prev) {
+				return false
+			}
+		}
+		return true
+	}
+	collect(len(layers)-1, goal)
+	return solutions
+}
+
+// find all boards reachable from the start board (including the start board itself) by a
+// breadth first search. At most maxBoards boards are collected (all of them if maxBoards is
+// 0), returns false if the enumeration was stopped before all reachable boards were found
+func ReachableBoards(start uint64, maxBoards int) ([]uint64, bool) {
+	seen := map[uint64]bool{start: true}
+	boards := []uint64{start}
+	for i := 0; i < len(boards); i++ {
+		for _, move := range defaultBoard.Moves {
+			next, ok := Apply(boards[i], move)
+			if !ok || seen[next] {
+				continue
+			}
+			if maxBoards > 0 && len(boards) >= maxBoards {
+				return boards, false
+			}
+			seen[next] = true
+			boards = append(boards, next)
+		}
+	}
+	return boards, true
+}
+
+// find all boards from which GOAL_BOARD is reached in exactly k moves, by a breadth first
+// search undoing moves starting at the goal. Since every move removes one peg, these are
+// the boards with k more pegs than the goal that can still be solved
+// Note: the layers grow quickly, the middle layers of the English board hold millions of
+// boards
+func BoardsAtReverseDepth(k int) []uint64 {
+	layer := []uint64{GOAL_BOARD}
+	for depth := 0; depth < k && len(layer) > 0; depth++ {
+		seen := map[uint64]bool{}
+		var next []uint64
+		for _, board := range layer {
+			for _, move := range defaultBoard.Moves {
+				prev, ok := Undo(board, move)
+				if !ok || seen[prev] {
+					continue
+				}
+				seen[prev] = true
+				next = append(next, prev)
+			}
+		}
+		layer = next
+	}
+	return layer
+}
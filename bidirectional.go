@@ -0,0 +1,94 @@
+package solitaire
+
+import (
+	"context"
+	"errors"
+)
+
+// returned by SolveBidirectional if it would have to keep more boards than allowed
+var ErrTooManyBoards = errors.New("too many boards to search in both directions")
+
+// find a solution by searching from both ends at once: forward from the start board and
+// backward (undoing moves) from the goal board. Every move removes one peg, so all boards
+// of a search frontier have the same peg count. The smaller frontier is expanded by one
+// move at a time until both frontiers hold boards with the same peg count, a board that is
+// in both of them connects the two halves of the solution
+// Note: unlike the depth first search this keeps all visited boards (together with the
+// board they were reached from) in memory, at most maxBoards of them (no limit if it is 0).
+// This works well for custom boards with up to about 24 pegs, the standard board would need
+// more than 40 million boards
+func SolveBidirectional(ctx context.Context, start uint64, goal uint64, maxBoards int) ([]uint64, error) {
+	if start == goal {
+		return []uint64{start}, nil
+	}
+	if !IsSolvable(start, goal) {
+		return nil, ErrNoSolution
+	}
+	// the board every board was reached from: its predecessor for the forward search and
+	// its successor for the backward search
+	forward := map[uint64]uint64{start: start}
+	backward := map[uint64]uint64{goal: goal}
+	forwardFrontier := []uint64{start}
+	backwardFrontier := []uint64{goal}
+	forwardPegs, backwardPegs := PegCount(start), PegCount(goal)
+
+	for forwardPegs > backwardPegs {
+		if len(forwardFrontier) == 0 || len(backwardFrontier) == 0 {
+			return nil, ErrNoSolution
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if maxBoards > 0 && len(forward)+len(backward) > maxBoards {
+			return nil, ErrTooManyBoards
+		}
+		if len(forwardFrontier) <= len(backwardFrontier) {
+			forwardFrontier = expandFrontier(forwardFrontier, forward, Apply)
+			forwardPegs--
+		} else {
+			backwardFrontier = expandFrontier(backwardFrontier, backward, Undo)
+			backwardPegs++
+		}
+	}
+	if forwardPegs != backwardPegs {
+		return nil, ErrNoSolution
+	}
+
+	for _, board := range forwardFrontier {
+		if _, found := backward[board]; !found {
+			continue
+		}
+		// walk back to the start board and then forward to the goal board
+		back := []uint64{}
+		for b := board; b != start; b = forward[b] {
+			back = append(back, b)
+		}
+		path := Reversed(append(back, start))
+		for b := board; b != goal; {
+			b = backward[b]
+			path = append(path, b)
+		}
+		return path, nil
+	}
+	return nil, ErrNoSolution
+}
+
+// get the boards reachable from the frontier by one step (applying or undoing a move) that
+// have not been visited yet, every new board is recorded in visited together with the board
+// it was reached from
+func expandFrontier(frontier []uint64, visited map[uint64]uint64, step func(uint64, Move) (uint64, bool)) []uint64 {
+	var next []uint64
+	for _, board := range frontier {
+		for _, move := range defaultBoard.Moves {
+			newBoard, ok := step(board, move)
+			if !ok {
+				continue
+			}
+			if _, found := visited[newBoard]; !found {
+				visited[newBoard] = board
+				next = append(next, newBoard)
+			}
+		}
+	}
+	return next
+}
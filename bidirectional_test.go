@@ -0,0 +1,39 @@
+package solitaire
+
+import (
+	"context"
+	"testing"
+)
+
+// the search from both ends finds a solution exactly when the depth first search does
+func TestSolveBidirectional(t *testing.T) {
+	// a single peg in d7 can not be reached from the boards after 20, 21 and 22 moves
+	goals := []uint64{GOAL_BOARD, 1 << coordToBit(6, 3)}
+	for _, moves := range []int{20, 21, 22} {
+		start := boardAfterMoves(t, moves)
+		for _, goal := range goals {
+			_, dfsErr := Solve(start, goal)
+			path, err := SolveBidirectional(context.Background(), start, goal, 0)
+			if err != dfsErr {
+				t.Errorf("after %d moves: got %v, the depth first search %v", moves, err, dfsErr)
+			}
+			if err == nil {
+				if verifyErr := VerifySolution(start, goal, path); verifyErr != nil {
+					t.Errorf("after %d moves: %v", moves, verifyErr)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveBidirectionalLimits(t *testing.T) {
+	start := boardAfterMoves(t, 14)
+	if _, err := SolveBidirectional(context.Background(), start, GOAL_BOARD, 100); err != ErrTooManyBoards {
+		t.Errorf("with 100 boards: got %v, want ErrTooManyBoards", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SolveBidirectional(ctx, start, GOAL_BOARD, 0); err != context.Canceled {
+		t.Errorf("canceled: got %v, want context.Canceled", err)
+	}
+}
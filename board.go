@@ -0,0 +1,196 @@
+package solitaire
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// a Board is the geometry of a variant: its valid cells and the moves along its lines of
+// three cells, with the lookup tables derived from the moves
+type Board struct {
+	// valid cells of the board
+	ValidCells uint64
+	// all moves that are possible (76 on the English board) in the order they are generated -
+	// this order is fixed, so it is used to refer to a move by its index
+	Moves []Move
+	// whether the lines wrap around the edges (see Variant.Torus) and whether they run along
+	// the diagonals (see Variant.Diagonal)
+	torus, diagonal bool
+	// the indices (into Moves) of the moves involving each cell, so only the moves around a
+	// cell have to be checked instead of all of them
+	movesByCell [49][]int
+	// the cells adjacent to each cell (as masks), derived from the moves: the cells of a move
+	// are next to each other in a row or column
+	adjacentCells [49]uint64
+	// whether the moves keep the color invariant: the lines wrapping around a torus (7 cells
+	// long, which is not a multiple of 3) do not
+	colorInvariantHolds bool
+}
+
+// create a board from its valid cells and the lines of three cells a move can jump along
+// (see MoveTriples), the moves are created in both directions of every line
+func NewBoard(validCells uint64, triples [][3]int) *Board {
+	moves := generateMoves(triples)
+	return &Board{
+		ValidCells:          validCells,
+		Moves:               moves,
+		movesByCell:         indexMovesByCell(moves),
+		adjacentCells:       indexAdjacentCells(moves),
+		colorInvariantHolds: movesKeepColors(moves),
+	}
+}
+
+// the key of a board is the set of occupied valid cells packed into consecutive
+// bits: bit i of the key is set if the i-th valid cell (counted from the lowest
+// bit of the valid cells of the board) holds a peg. Unlike the raw bitmap it does not depend
+// on the position of the cells in the 7 x 7 layout, so it can be used to compare
+// or cache boards no matter which bit layout they were created in
+func BoardKey(board uint64) uint64 {
+	var key uint64
+	var bit uint64 = 1
+	for valid := defaultBoard.ValidCells; valid != 0; valid &= valid - 1 {
+		if (board & valid & -valid) != 0 {
+			key |= bit
+		}
+		bit <<= 1
+	}
+	return key
+}
+
+// convert a board key (see BoardKey) back into a board
+func KeyBoard(key uint64) uint64 {
+	var board uint64
+	for valid := defaultBoard.ValidCells; valid != 0 && key != 0; valid &= valid - 1 {
+		if (key & 1) != 0 {
+			board |= valid & -valid
+		}
+		key >>= 1
+	}
+	return board
+}
+
+// get the row and column of a cell (bit index), row 0 is the top line of the
+// printed board and column 0 the leftmost slot
+func bitToCoord(cell int) (int, int) {
+	return cell / 7, cell % 7
+}
+
+// get the cell (bit index) at the given row and column
+func coordToBit(row int, col int) int {
+	cell := 7*row + col
+	checkCell(cell)
+	return cell
+}
+
+// get the valid cells next to a cell in its row and column, in the order above, left,
+// right and below (cells at the edge of the cross have fewer neighbors)
+func Neighbors(cell int) []int {
+	row, col := bitToCoord(cell)
+	var neighbors []int
+	for _, d := range [4][2]int{{-1, 0}, {0, -1}, {0, 1}, {1, 0}} {
+		if r, c := row+d[0], col+d[1]; IsValidCell(r, c) {
+			neighbors = append(neighbors, coordToBit(r, c))
+		}
+	}
+	return neighbors
+}
+
+// check whether the cell at the given row and column (see SetCell) is part of the board,
+// anything outside of the 7 x 7 grid is not
+func IsValidCell(row int, col int) bool {
+	return row >= 0 && row <= 6 && col >= 0 && col <= 6 && IsValidBit(coordToBit(row, col))
+}
+
+// check whether the cell with the given bit index is part of the board
+func IsValidBit(cell int) bool {
+	return cell >= 0 && cell < 49 && defaultBoard.ValidCells&(1<<cell) != 0
+}
+
+// put a peg into the cell at the given row and column (as printed, row 0 is the top line
+// and column 0 the leftmost slot)
+// Note: the binary strings in solitaire.go start with the highest bit, so they show the
+// board upside down and mirrored compared to these coordinates (bit 0 is the top left
+// cell, the leading "0" of the strings is only an unused 50th bit); constructing boards with
+// SetCell and ClearCell avoids having to care about this
+func SetCell(board uint64, row int, col int) uint64 {
+	return board | 1<<gridCell(row, col)
+}
+
+// remove the peg from the cell at the given row and column (see SetCell)
+func ClearCell(board uint64, row int, col int) uint64 {
+	return board &^ (1 << gridCell(row, col))
+}
+
+// get the cell (bit index) at the given row and column, panics if they are not inside the
+// 7 x 7 grid (the cell would silently end up in another row otherwise)
+func gridCell(row int, col int) int {
+	if row < 0 || row > 6 || col < 0 || col > 6 {
+		panic(fmt.Sprintf("row %d, column %d is not inside the board", row, col))
+	}
+	return coordToBit(row, col)
+}
+
+// get the cell (bit index) in the middle of the smallest rectangle around the valid cells
+// of the current variant, i.e. the center of the board (24 or d4 on the English board); for
+// an even number of rows or columns the upper or left of the two middle ones is used
+func CenterCell() int {
+	return defaultBoard.CenterCell()
+}
+
+// get the center cell of the board, see CenterCell
+func (b *Board) CenterCell() int {
+	minRow, minCol, maxRow, maxCol := 6, 6, 0, 0
+	for valid := b.ValidCells; valid != 0; valid &= valid - 1 {
+		row, col := bitToCoord(bits.TrailingZeros64(valid))
+		minRow, minCol = min(minRow, row), min(minCol, col)
+		maxRow, maxCol = max(maxRow, row), max(maxCol, col)
+	}
+	return coordToBit((minRow+maxRow)/2, (minCol+maxCol)/2)
+}
+
+// get the board with a peg in every valid cell
+func FullBoard() uint64 {
+	return defaultBoard.ValidCells
+}
+
+// get the board with a peg in every valid cell except the given one, the start of the
+// classic puzzles (the standard puzzle is FullBoardExcept(24), i.e. the center d4)
+func FullBoardExcept(cell int) uint64 {
+	checkCell(cell)
+	return defaultBoard.ValidCells &^ (1 << cell)
+}
+
+// get the holes of a board, i.e. the valid cells without a peg
+func Holes(board uint64) uint64 {
+	return defaultBoard.ValidCells &^ board
+}
+
+// check that all pegs of a board are on valid cells, returns ErrInvalidCell naming the
+// first peg outside of the board otherwise
+func CheckBoard(board uint64) error {
+	return checkCells(board, defaultBoard.ValidCells)
+}
+
+// check that all pegs of a board are on the given cells (see CheckBoard)
+func checkCells(board uint64, valid uint64) error {
+	invalid := board &^ valid
+	if invalid == 0 {
+		return nil
+	}
+	cell := bits.TrailingZeros64(invalid)
+	if cell < 49 {
+		return fmt.Errorf("%w: %s", ErrInvalidCell, cellName(cell))
+	}
+	return fmt.Errorf("%w: bit %d", ErrInvalidCell, cell)
+}
+
+// the highest cell index that fits into the 64 bits of a board
+const maxCell = 63
+
+// panic if a cell index does not fit into a board - shifting by a larger index would
+// silently produce a wrong (empty) mask instead
+func checkCell(cell int) {
+	if cell < 0 || cell > maxCell {
+		panic(fmt.Sprintf("cell index %d does not fit into the board", cell))
+	}
+}
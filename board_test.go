@@ -0,0 +1,138 @@
+package solitaire
+
+import (
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// the key of a board holds one bit per valid cell (33 on the English board), in the order
+// of the cells in the 7 x 7 layout, and converts back into the same board
+func TestBoardKey(t *testing.T) {
+	if key := BoardKey(FullBoard()); key != 1<<33-1 {
+		t.Errorf("BoardKey(FullBoard()) = %#x, want the 33 lowest bits", key)
+	}
+	// d4 is the 17th valid cell, after the 3 + 3 cells of the top arm and the 7 + 3 cells of
+	// row 3 before it
+	if key := BoardKey(GOAL_BOARD); key != 1<<16 {
+		t.Errorf("BoardKey(GOAL_BOARD) = %#x, want %#x", key, 1<<16)
+	}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		board := random.Uint64() & defaultBoard.ValidCells
+		key := BoardKey(board)
+		if key >= 1<<33 || bits.OnesCount64(key) != PegCount(board) {
+			t.Errorf("BoardKey(%#x) = %#x is not a set of valid cells", board, key)
+		}
+		if back := KeyBoard(key); back != board {
+			t.Errorf("KeyBoard(BoardKey(%#x)) = %#x", board, back)
+		}
+		// and every set of valid cells in the dense layout is the key of a board
+		if dense := random.Uint64() & (1<<33 - 1); BoardKey(KeyBoard(dense)) != dense {
+			t.Errorf("BoardKey(KeyBoard(%#x)) = %#x", dense, BoardKey(KeyBoard(dense)))
+		}
+	}
+}
+
+func TestFullBoard(t *testing.T) {
+	if pegs := PegCount(FullBoard()); pegs != 33 {
+		t.Errorf("FullBoard has %d pegs, want 33", pegs)
+	}
+	for cell := 0; cell < 49; cell++ {
+		if !IsValidBit(cell) {
+			continue
+		}
+		board := FullBoardExcept(cell)
+		if PegCount(board) != 32 || board&(1<<cell) != 0 {
+			t.Errorf("FullBoardExcept(%s) has %d pegs, want 32 with %s empty", cellName(cell), PegCount(board), cellName(cell))
+		}
+	}
+	if FullBoardExcept(CenterCell()) != INITIAL_BOARD {
+		t.Errorf("FullBoardExcept(CenterCell()) is not the start of the standard puzzle")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	tests := []struct {
+		row, col int
+		want     []int
+	}{
+		// the center has all four neighbors, the middle of the end of an arm three, its
+		// corners two
+		{3, 3, []int{coordToBit(2, 3), coordToBit(3, 2), coordToBit(3, 4), coordToBit(4, 3)}},
+		{0, 3, []int{coordToBit(0, 2), coordToBit(0, 4), coordToBit(1, 3)}},
+		{0, 2, []int{coordToBit(0, 3), coordToBit(1, 2)}},
+		{4, 6, []int{coordToBit(3, 6), coordToBit(4, 5)}},
+		{2, 2, []int{coordToBit(1, 2), coordToBit(2, 1), coordToBit(2, 3), coordToBit(3, 2)}},
+	}
+	for _, test := range tests {
+		if got := Neighbors(coordToBit(test.row, test.col)); !slices.Equal(got, test.want) {
+			t.Errorf("Neighbors(%s) = %v, want %v", cellName(coordToBit(test.row, test.col)), got, test.want)
+		}
+	}
+}
+
+// on a cross with arms one cell wide the tip of an arm has a single neighbor
+func TestNeighborsNarrowCross(t *testing.T) {
+	defer func(board *Board) { defaultBoard = board }(defaultBoard)
+	var cells uint64
+	for i := 0; i < 7; i++ {
+		cells |= 1<<coordToBit(i, 3) | 1<<coordToBit(3, i)
+	}
+	defaultBoard = NewBoard(cells, MoveTriples(cells))
+	if got := Neighbors(coordToBit(0, 3)); !slices.Equal(got, []int{coordToBit(1, 3)}) {
+		t.Errorf("Neighbors(d1) = %v, want only d2", got)
+	}
+	if got := Neighbors(coordToBit(3, 3)); len(got) != 4 {
+		t.Errorf("Neighbors(d4) = %v, want 4 neighbors", got)
+	}
+}
+
+// the boards of two variants are used side by side, each one with its own cells and moves
+func TestNewBoard(t *testing.T) {
+	english, european := Variants["english"].Board(), Variants["european"].Board()
+	if len(english.Moves) != 76 || len(european.Moves) != 92 {
+		t.Errorf("got %d English and %d European moves, want 76 and 92", len(english.Moves), len(european.Moves))
+	}
+	if got, want := english.boardLines(european.ValidCells)[1], "  XXX"; got != want {
+		t.Errorf("got the second English line %q, want %q", got, want)
+	}
+	if got, want := european.boardLines(english.ValidCells)[1], " 0XXX0"; got != want {
+		t.Errorf("got the second European line %q, want %q", got, want)
+	}
+	if got := european.ComplementBoard(english.ValidCells); bits.OnesCount64(got) != 4 {
+		t.Errorf("the complement of the full English board has %d European pegs, want 4", bits.OnesCount64(got))
+	}
+	if english.holeNumber(24) != 17 || european.holeNumber(24) != 19 {
+		t.Errorf("the center is hole %d on the English and %d on the European board, want 17 and 19", english.holeNumber(24), european.holeNumber(24))
+	}
+	if !english.colorInvariantHolds || Variants["english"].OnTorus().Board().colorInvariantHolds {
+		t.Error("the color invariant holds only for the English board, not the torus")
+	}
+}
+
+func TestBitToCoord(t *testing.T) {
+	for cell := 0; cell < 49; cell++ {
+		row, col := bitToCoord(cell)
+		if row < 0 || row > 6 || col < 0 || col > 6 || coordToBit(row, col) != cell {
+			t.Errorf("cell %d is at row %d, column %d", cell, row, col)
+		}
+	}
+}
+
+// the sink of the conversion benchmarks, so the conversions are not optimized away
+var coordSink int
+
+func BenchmarkBitToCoord(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		row, col := bitToCoord(i % 49)
+		coordSink += row + col
+	}
+}
+
+func BenchmarkCoordToBit(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		coordSink += coordToBit(i%7, i/7%7)
+	}
+}
@@ -0,0 +1,85 @@
+package solitaire
+
+import (
+	"container/heap"
+	"math/bits"
+)
+
+// get the spread of the pegs of a board: the area of the smallest rectangle holding all of
+// them (0 for the empty board)
+func Spread(board uint64) int {
+	if board == 0 {
+		return 0
+	}
+	minRow, minCol, maxRow, maxCol := 6, 6, 0, 0
+	for pegs := board; pegs != 0; pegs &= pegs - 1 {
+		row, col := bitToCoord(bits.TrailingZeros64(pegs))
+		minRow, minCol = min(minRow, row), min(minCol, col)
+		maxRow, maxCol = max(maxRow, row), max(maxCol, col)
+	}
+	return (maxRow - minRow + 1) * (maxCol - minCol + 1)
+}
+
+// get the sum of the spreads of all boards of a path, the lower the more clustered the pegs
+// stay during the solution
+func PathSpread(path []uint64) int {
+	spread := 0
+	for _, board := range path {
+		spread += Spread(board)
+	}
+	return spread
+}
+
+// look for a solution keeping the pegs clustered (with a low PathSpread) by a best first
+// search: the boards are expanded in the order of the spread of the path leading to them
+// plus an estimate for the rest of the solution, which assumes that the spread of the
+// remaining boards is half the spread of the board on average (the pegs keep moving closer
+// together towards the goal)
+// Note: this is a heuristic, the spreads of later boards can be lower than the estimate and
+// every board keeps the first path found to it, so the solution is not always the most
+// clustered one. All visited boards are kept in memory, on boards where the estimate is
+// misleading this needs much more time and memory than the depth first search
+func (s *Solver) searchClustered(start uint64) {
+	goal := s.goal.Load()
+	goalPegs := PegCount(goal)
+	type reachedBoard struct {
+		parent uint64
+		spread int
+	}
+	reached := map[uint64]reachedBoard{start: {start, Spread(start)}}
+	open := &boardQueue{{board: start}}
+	for open.Len() > 0 {
+		if s.nodes.Add(1)%contextCheckInterval == 0 && s.ctx.Err() != nil {
+			s.err = s.ctx.Err()
+			return
+		}
+		node := heap.Pop(open).(queuedBoard)
+		s.visit(node.board)
+		if node.board == goal {
+			path := []uint64{goal}
+			for board := goal; board != start; {
+				board = reached[board].parent
+				path = append(path, board)
+			}
+			s.Solution = Reversed(path)
+			return
+		}
+		if PegCount(node.board) <= goalPegs {
+			continue
+		}
+		spread := reached[node.board].spread
+		for _, move := range s.moves {
+			next, ok := Apply(node.board, move)
+			if !ok || !s.allows(node.board, move) || (next != goal && s.Prune != nil && s.Prune(next)) {
+				continue
+			}
+			if _, found := reached[next]; found {
+				continue
+			}
+			nextSpread := spread + Spread(next)
+			reached[next] = reachedBoard{node.board, nextSpread}
+			estimate := nextSpread + Spread(next)*(PegCount(next)-goalPegs)/2
+			heap.Push(open, queuedBoard{board: next, g: node.g + 1, f: estimate})
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// the command line program of the solver, see the README for its flags
+package main
+
+import (
+	"os"
+
+	"github.com/schur/solitaire"
+)
+
+func main() {
+	solitaire.Main(os.Args[1:])
+}
@@ -0,0 +1,31 @@
+package solitaire
+
+// the complement of a board swaps pegs and holes. A jump moves a peg over a peg into a
+// hole, undoing it on the complement moves a hole over a hole into a peg - which is a jump
+// again. So a solution from start to goal read backwards and complemented is a solution
+// from the complement of the goal to the complement of the start, i.e. the complement
+// puzzle is solvable exactly if the original one is. The standard puzzle is its own
+// complement: the complement of the single peg in the center is the board with only the
+// center empty
+// Note: this does not hold for the complement of the start board alone (with the original
+// goal), e.g. the complement of the standard start is the goal itself
+
+// get the complement of a board, i.e. the board with a peg in every hole and vice versa
+func (b *Board) ComplementBoard(board uint64) uint64 {
+	return b.ValidCells &^ board
+}
+
+// get the complement puzzle of a puzzle given by its start and goal board: it starts
+// with the complement of the goal and ends with the complement of the start
+func ComplementPuzzle(start uint64, goal uint64) (uint64, uint64) {
+	return defaultBoard.ComplementBoard(goal), defaultBoard.ComplementBoard(start)
+}
+
+// get the solution of the complement puzzle from a solution of the original one
+func ComplementSolution(path []uint64) []uint64 {
+	complement := Reversed(path)
+	for i, board := range complement {
+		complement[i] = defaultBoard.ComplementBoard(board)
+	}
+	return complement
+}
@@ -0,0 +1,91 @@
+package solitaire
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+)
+
+// a solution can be stored compactly as its start board plus the index of each
+// move (in the fixed generation order of Board.Moves) instead of every board
+
+// get the index of a move in the generation order, or -1 if it is no valid move
+func MoveIndex(move Move) int {
+	for i, m := range defaultBoard.Moves {
+		if m == move {
+			return i
+		}
+	}
+	return -1
+}
+
+// compress a solution path into its start board and the indices of its moves
+func CompressSolution(path []uint64) (uint64, []uint8) {
+	if len(path) == 0 {
+		return 0, nil
+	}
+	moves := SolutionMoves(path)
+	idx := make([]uint8, len(moves))
+	for i, move := range moves {
+		idx[i] = uint8(MoveIndex(move))
+	}
+	return path[0], idx
+}
+
+// rebuild the boards of a compressed solution by applying its moves to the start board
+// if a move index is out of range or the move is not legal, the boards up to that
+// move are returned
+func ExpandSolution(start uint64, idx []uint8) []uint64 {
+	path := make([]uint64, 1, len(idx)+1)
+	path[0] = start
+	board := start
+	for _, i := range idx {
+		if int(i) >= len(defaultBoard.Moves) {
+			break
+		}
+		next, ok := Apply(board, defaultBoard.Moves[i])
+		if !ok {
+			break
+		}
+		board = next
+		path = append(path, board)
+	}
+	return path
+}
+
+// export a solution as a single line of text for sharing: the key of the start board (see
+// BoardKey) and the move indices in hexadecimal followed by a CRC-32 checksum of both,
+// e.g. "c000:11:cc3da942" (b4-d4 with pegs only in b4 and c4)
+func ExportSolution(path []uint64) string {
+	start, idx := CompressSolution(path)
+	content := strconv.FormatUint(BoardKey(start), 16) + ":" + hex.EncodeToString(idx)
+	return fmt.Sprintf("%s:%08x", content, crc32.ChecksumIEEE([]byte(content)))
+}
+
+// import a solution exported by ExportSolution, returns an error if the checksum does
+// not match or the moves can not be replayed on the start board
+func ImportSolution(text string) ([]uint64, error) {
+	parts := strings.Split(strings.TrimSpace(text), ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid solution %q: expected start:moves:checksum", text)
+	}
+	content := parts[0] + ":" + parts[1]
+	if want := fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(content))); parts[2] != want {
+		return nil, fmt.Errorf("checksum mismatch: got %s, expected %s", parts[2], want)
+	}
+	key, err := strconv.ParseUint(parts[0], 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start board %q", parts[0])
+	}
+	idx, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid moves %q", parts[1])
+	}
+	path := ExpandSolution(KeyBoard(key), idx)
+	if len(path) != len(idx)+1 {
+		return nil, fmt.Errorf("move %d can not be replayed", len(path))
+	}
+	return path, nil
+}
@@ -0,0 +1,47 @@
+package solitaire
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpandSolution(t *testing.T) {
+	path, err := ReplayNotation(INITIAL_BOARD, englishSolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, idx := CompressSolution(path)
+	if start != INITIAL_BOARD || len(idx) != 31 {
+		t.Fatalf("CompressSolution gives start %#x and %d moves", start, len(idx))
+	}
+	if expanded := ExpandSolution(start, idx); !slices.Equal(expanded, path) {
+		t.Errorf("the expanded solution differs from the compressed one")
+	}
+	// the boards up to a move that is out of range or not legal
+	broken := append([]uint8(nil), idx...)
+	broken[5] = uint8(len(defaultBoard.Moves))
+	if expanded := ExpandSolution(start, broken); !slices.Equal(expanded, path[:6]) {
+		t.Errorf("expanding with move 6 out of range gives %d boards, want 6", len(expanded))
+	}
+	broken[5] = idx[4]
+	if expanded := ExpandSolution(start, broken); !slices.Equal(expanded, path[:6]) {
+		t.Errorf("expanding with move 6 not legal gives %d boards, want 6", len(expanded))
+	}
+}
+
+func TestImportSolution(t *testing.T) {
+	path, err := ReplayNotation(INITIAL_BOARD, englishSolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := ExportSolution(path)
+	if imported, err := ImportSolution(text); err != nil || !slices.Equal(imported, path) {
+		t.Errorf("ImportSolution(%q) = %d boards, %v", text, len(imported), err)
+	}
+	// a changed move does not match the checksum
+	changed := []byte(text)
+	changed[len(changed)-12] ^= 1
+	if _, err := ImportSolution(string(changed)); err == nil {
+		t.Errorf("ImportSolution(%q) accepts a changed solution", changed)
+	}
+}
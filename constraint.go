@@ -0,0 +1,88 @@
+package solitaire
+
+// a constraint restricts the moves a solution may use, see Solver.Constraints
+type Constraint interface {
+	// check whether the move may be made on the board (the board before the move in
+	// playing order)
+	Allows(board uint64, move Move) bool
+}
+
+// a constraint that does not depend on the board, so it is applied to the moves once
+// before the search instead of at every visited board
+type moveConstraint interface {
+	Constraint
+	allowsMove(move Move) bool
+}
+
+// the pegs in these cells may neither be moved nor jumped over (see Solver.FixedCells)
+type FixedCellsConstraint uint64
+
+func (c FixedCellsConstraint) Allows(board uint64, move Move) bool {
+	return c.allowsMove(move)
+}
+
+func (c FixedCellsConstraint) allowsMove(move Move) bool {
+	return move.all&uint64(c) == 0
+}
+
+// only the moves within these cells are allowed (see Solver.ValidCells)
+type RegionConstraint uint64
+
+func (c RegionConstraint) Allows(board uint64, move Move) bool {
+	return c.allowsMove(move)
+}
+
+func (c RegionConstraint) allowsMove(move Move) bool {
+	return move.all&^uint64(c) == 0
+}
+
+// collect the constraints of the next search: the ones given by FixedCells and ValidCells
+// followed by Constraints
+func (s *Solver) constraints(valid uint64) []Constraint {
+	var constraints []Constraint
+	if s.FixedCells != 0 {
+		constraints = append(constraints, FixedCellsConstraint(s.FixedCells))
+	}
+	if valid != s.Board.ValidCells {
+		constraints = append(constraints, RegionConstraint(valid))
+	}
+	return append(constraints, s.Constraints...)
+}
+
+// prepare the moves and constraints of the next search: the moves rejected by a constraint
+// that does not depend on the board are dropped, the other constraints are kept to be
+// checked during the search
+func (s *Solver) applyConstraints(constraints []Constraint) {
+	s.moves = s.Moves
+	s.boardConstraints = nil
+	filtered := false
+	for _, constraint := range constraints {
+		c, ok := constraint.(moveConstraint)
+		if !ok {
+			s.boardConstraints = append(s.boardConstraints, constraint)
+			continue
+		}
+		if !filtered {
+			// do not change the order of Moves
+			s.moves = append([]Move(nil), s.moves...)
+			filtered = true
+		}
+		moves := s.moves[:0]
+		for _, move := range s.moves {
+			if c.allowsMove(move) {
+				moves = append(moves, move)
+			}
+		}
+		s.moves = moves
+	}
+}
+
+// check the constraints that depend on the board for a move made on the board
+func (s *Solver) allows(board uint64, move Move) bool {
+	for _, constraint := range s.boardConstraints {
+		if !constraint.Allows(board, move) {
+			return false
+		}
+	}
+	return true
+}
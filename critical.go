@@ -0,0 +1,81 @@
+package solitaire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// how many of the legal moves of a board of a solution keep the goal reachable
+type MoveChoices struct {
+	// the number of the move made on the board (starting at 1)
+	Step int
+	Move Move
+	// the legal moves of the board, how many of them lead to a board that can still be
+	// solved and how many could not be decided in time
+	Legal   int
+	Safe    int
+	Unknown int
+}
+
+// check whether a wrong choice on the board of a move loses: most of its legal moves lead
+// to dead ends (and there is a choice at all)
+func (c MoveChoices) Critical() bool {
+	return c.Legal > 1 && 2*(c.Safe+c.Unknown) <= c.Legal
+}
+
+// count for every move of a solution how many of the legal moves of its board keep the goal
+// reachable, by solving from every successor board (for at most "timeout" each). The
+// results are kept in a transposition table, which answers the successors that are boards
+// of the solution or of an earlier successor's solution without searching again
+// Note: proving that a board with many pegs can not be solved takes long, so on the English
+// board the early moves may end up as unknown
+func AnalyzeChoices(path []uint64, timeout time.Duration) []MoveChoices {
+	if len(path) == 0 {
+		return nil
+	}
+	goal := path[len(path)-1]
+	table := NewTranspositionTable()
+	table.storeSolution(path)
+	choices := make([]MoveChoices, 0, len(path))
+	for i := 0; i+1 < len(path); i++ {
+		choice := MoveChoices{Step: i + 1, Move: moveBetween(path[i], path[i+1])}
+		for _, move := range LegalMoves(path[i]) {
+			choice.Legal++
+			next, _ := Apply(path[i], move)
+			solver := NewSolver()
+			solver.SetOrder("random")
+			solver.Table = table
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			_, err := solver.Solve(ctx, next, goal)
+			cancel()
+			if err == nil {
+				choice.Safe++
+			} else if !errors.Is(err, ErrNoSolution) {
+				choice.Unknown++
+			}
+		}
+		choices = append(choices, choice)
+	}
+	return choices
+}
+
+// print the critical moves of a solution (see MoveChoices.Critical) with their counts
+func PrintCriticalMoves(path []uint64, timeout time.Duration) {
+	found := false
+	for _, choice := range AnalyzeChoices(path, timeout) {
+		if !choice.Critical() {
+			continue
+		}
+		found = true
+		fmt.Printf("move %d (%s): %d of %d legal moves keep the puzzle solvable", choice.Step, MoveString(choice.Move), choice.Safe, choice.Legal)
+		if choice.Unknown > 0 {
+			fmt.Printf(", %d unknown", choice.Unknown)
+		}
+		fmt.Println()
+	}
+	if !found {
+		fmt.Println("no critical moves: on every board at least half of the legal moves keep the puzzle solvable")
+	}
+}
@@ -0,0 +1,44 @@
+package solitaire
+
+import (
+	"fmt"
+	"strings"
+)
+
+// describe a board in words (e.g. for screen readers), one line per row like
+// "Row 1: c1 peg, d1 empty, e1 peg" - the cell names match the move notation
+func DescribeBoard(board uint64) string {
+	var text strings.Builder
+	for line := 0; line < 7; line++ {
+		var cells []string
+		// loop over all cells of the line (the same way printLine does)
+		checkCell(7*line + 6)
+		var cell uint64 = 1 << (7 * line)
+		for i := 0; i < 7; i++ {
+			if (cell & defaultBoard.ValidCells) != 0 {
+				state := "empty"
+				if (cell & board) != 0 {
+					state = "peg"
+				}
+				cells = append(cells, cellName(7*line+i)+" "+state)
+			}
+			cell = cell << 1
+		}
+		if len(cells) > 0 {
+			fmt.Fprintf(&text, "Row %d: %s\n", line+1, strings.Join(cells, ", "))
+		}
+	}
+	return text.String()
+}
+
+// print a solution in words: the start board followed by each move and the resulting board
+func DescribeSolution(solution []uint64) {
+	for i, board := range solution {
+		if i == 0 {
+			fmt.Println("Start board:")
+		} else {
+			fmt.Printf("Move %d: %s\n", i, MoveDescription(moveBetween(solution[i-1], board)))
+		}
+		fmt.Print(DescribeBoard(board))
+	}
+}
@@ -0,0 +1,53 @@
+package solitaire
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// let the user build a start board interactively, beginning with the given board: every
+// cell name entered (e.g. "d4") toggles the peg of that cell, "fill" and "clear" fill or
+// empty the whole board, "solve" finishes. Returns false if the user quits instead
+func EditBoard(board uint64, in io.Reader) (uint64, bool) {
+	fmt.Println("enter a cell (e.g. d4) to toggle its peg, \"fill\", \"clear\", \"solve\" or \"quit\"")
+	printBoard(board, board)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			return board, false
+		}
+		input := strings.TrimSpace(scanner.Text())
+		next := board
+		switch input {
+		case "":
+			continue
+		case "quit":
+			return board, false
+		case "solve":
+			return board, true
+		case "fill":
+			next = FullBoard()
+		case "clear":
+			next = 0
+		default:
+			cell, err := parseCell(input)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			row, col := bitToCoord(cell)
+			if board&(1<<cell) != 0 {
+				next = ClearCell(board, row, col)
+			} else {
+				next = SetCell(board, row, col)
+			}
+		}
+		printBoard(next, board)
+		fmt.Printf("%d peg(s), %d legal move(s)\n", PegCount(next), Mobility(next))
+		board = next
+	}
+}
@@ -0,0 +1,121 @@
+package solitaire
+
+// solutions are enumerated and counted as sequences of boards, every move is one edge. Two
+// different legal moves never lead from a board to the same next board: a move changes
+// exactly its three cells, and on a given board the cells holding pegs decide which of them
+// is the destination. Only a move list holding the same move twice (e.g. from a triple that
+// was given twice, or with its cells in another order) has such collisions, so they are
+// merged by generateMoves and can be found in other move lists with SuccessorCollisions
+
+// call fn for every solution leading from the start to the goal board, in depth first
+// order and without keeping the solutions in memory. The enumeration stops as soon as fn
+// returns false; returns false if it was stopped this way
+// Note: the path passed to fn is reused for the next solution, fn has to copy it to keep it
+func EnumerateSolutions(start uint64, goal uint64, fn func(path []uint64) bool) bool {
+	e := enumerator{
+		goal:     goal,
+		goalPegs: PegCount(goal),
+		fn:       fn,
+		dead:     map[uint64]bool{},
+		path:     append(make([]uint64, 0, PegCount(start)), start),
+	}
+	if IsSolvable(start, goal) {
+		e.walk(start)
+	}
+	return !e.stopped
+}
+
+// count the solutions leading from the start to the goal board by enumerating them. If
+// progress is not nil, it is called after every "every" solutions with the number of
+// solutions and visited boards so far
+// Note: the standard board has far too many solutions to count them this way
+func CountSolutions(start uint64, goal uint64, every uint64, progress func(solutions uint64, nodes uint64)) uint64 {
+	if every == 0 {
+		every = 1
+	}
+	e := enumerator{
+		goal:     goal,
+		goalPegs: PegCount(goal),
+		fn:       func([]uint64) bool { return true },
+		dead:     map[uint64]bool{},
+		path:     append(make([]uint64, 0, PegCount(start)), start),
+		every:    every,
+		progress: progress,
+	}
+	if IsSolvable(start, goal) {
+		e.walk(start)
+	}
+	return e.solutions
+}
+
+// state of EnumerateSolutions
+type enumerator struct {
+	goal     uint64
+	goalPegs int
+	fn       func(path []uint64) bool
+	// boards from which the goal cannot be reached
+	dead    map[uint64]bool
+	path    []uint64
+	stopped bool
+	// number of solutions and visited boards so far
+	solutions uint64
+	nodes     uint64
+	// optional progress report after every "every" solutions
+	every    uint64
+	progress func(solutions uint64, nodes uint64)
+}
+
+// visit all solutions continuing the current path with the given board (the last board of
+// the path), returns true if there is at least one
+func (e *enumerator) walk(board uint64) bool {
+	e.nodes++
+	if board == e.goal {
+		e.solutions++
+		if e.progress != nil && e.solutions%e.every == 0 {
+			e.progress(e.solutions, e.nodes)
+		}
+		if !e.fn(e.path) {
+			e.stopped = true
+		}
+		return true
+	}
+	if PegCount(board) <= e.goalPegs || e.dead[board] {
+		return false
+	}
+	found := false
+	for _, move := range defaultBoard.Moves {
+		next, ok := Apply(board, move)
+		if !ok {
+			continue
+		}
+		e.path = append(e.path, next)
+		if e.walk(next) {
+			found = true
+		}
+		e.path = e.path[:len(e.path)-1]
+		if e.stopped {
+			return true
+		}
+	}
+	if !found {
+		e.dead[board] = true
+	}
+	return found
+}
+
+// find the pairs of legal moves (as indices into moves) that lead from the board to the same
+// next board, see the note on the enumeration at the top
+func SuccessorCollisions(board uint64, moves []Move) [][2]int {
+	var collisions [][2]int
+	for i := range moves {
+		if _, ok := Apply(board, moves[i]); !ok {
+			continue
+		}
+		for j := i + 1; j < len(moves); j++ {
+			if _, ok := Apply(board, moves[j]); ok && moves[i].all == moves[j].all {
+				collisions = append(collisions, [2]int{i, j})
+			}
+		}
+	}
+	return collisions
+}
@@ -0,0 +1,184 @@
+package solitaire
+
+import "math/bits"
+
+// the exhaustive searches look at the whole state space instead of stopping at the first
+// solution. Boards that are rotations/reflections of each other (under the transforms mapping
+// the goal board and the cells of the search onto themselves) are equally far from the goal
+// and have the same number of solutions, so they are kept as one entry (see seenKey)
+
+// get the number of moves of a path when consecutive jumps by the same peg are merged into
+// one move, as in the usual rules of the puzzle (the standard puzzle of the English board
+// takes at least 18 such moves)
+func MoveCount(path []uint64) int {
+	count, last := 0, -1
+	for _, move := range SolutionMoves(path) {
+		from, _, to := moveCells(move)
+		if from != last {
+			count++
+		}
+		last = to
+	}
+	return count
+}
+
+// find the solution with the fewest moves (see MoveCount) by a breadth first search over
+// the moves: every layer holds the boards first reached with one move more than the boards
+// of the layer before, the first layer reaching the goal gives the solution
+// Note: the search keeps every board reached in memory, for the standard puzzle of the
+// English board these are millions of boards even with the symmetry reduction, and finding
+// its 18 move solution takes a few minutes
+func (s *Solver) searchFewestMoves(start uint64) {
+	w := fewestMovesSearch{
+		s:        s,
+		goal:     s.goal.Load(),
+		goalPegs: PegCount(s.goal.Load()),
+	}
+	for _, move := range s.moves {
+		from, _, _ := moveCells(move)
+		w.byOrigin[from] = append(w.byOrigin[from], move)
+	}
+	s.symmetries = s.searchSymmetries(w.goal, w.goal)
+	startKey, goalKey := s.seenKey(start), s.seenKey(w.goal)
+	// the board of the previous layer every board was first reached from
+	parent := map[uint64]uint64{startKey: startKey}
+	found := false
+	for layer := []uint64{startKey}; len(layer) > 0 && !found && s.err == nil; {
+		var next []uint64
+		for _, board := range layer {
+			s.visit(board)
+			w.moves(board, func(jumps []uint64) bool {
+				if s.nodes.Add(1)%contextCheckInterval == 0 && s.ctx.Err() != nil {
+					s.err = s.ctx.Err()
+					return false
+				}
+				key := s.seenKey(jumps[len(jumps)-1])
+				if _, seen := parent[key]; seen {
+					return true
+				}
+				parent[key] = board
+				next = append(next, key)
+				found = key == goalKey
+				return !found
+			})
+			if found || s.err != nil {
+				break
+			}
+		}
+		layer = next
+	}
+	if !found {
+		return
+	}
+	// follow the parents back to the start, then find moves from the start (which is not
+	// transformed) leading to the boards of the layers
+	keys := []uint64{goalKey}
+	for key := goalKey; key != startKey; {
+		key = parent[key]
+		keys = append(keys, key)
+	}
+	s.Solution = []uint64{start}
+	board := start
+	for i := len(keys) - 2; i >= 0; i-- {
+		w.moves(board, func(jumps []uint64) bool {
+			if s.seenKey(jumps[len(jumps)-1]) != keys[i] {
+				return true
+			}
+			s.Solution = append(s.Solution, jumps...)
+			board = jumps[len(jumps)-1]
+			return false
+		})
+	}
+}
+
+// state of searchFewestMoves
+type fewestMovesSearch struct {
+	s        *Solver
+	goal     uint64
+	goalPegs int
+	// the moves of the solver by the cell of the jumping peg
+	byOrigin [49][]Move
+}
+
+// call fn for every move on the board with the boards after each of its jumps, leaving out
+// moves to boards from which the goal can not be reached by the peg count or the color
+// invariant. Stops as soon as fn returns false; returns false if it was stopped this way
+func (w *fewestMovesSearch) moves(board uint64, fn func(jumps []uint64) bool) bool {
+	for pegs := board; pegs != 0; pegs &= pegs - 1 {
+		if !w.jumps(board, bits.TrailingZeros64(pegs), nil, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// call fn for every chain of jumps by the peg in the cell continuing the given jumps, see
+// moves
+func (w *fewestMovesSearch) jumps(board uint64, cell int, jumps []uint64, fn func(jumps []uint64) bool) bool {
+	s := w.s
+	if PegCount(board) <= w.goalPegs {
+		return true
+	}
+	for _, move := range w.byOrigin[cell] {
+		next, ok := Apply(board, move)
+		if !ok || !s.allows(board, move) || !s.Board.IsSolvable(next, w.goal) || (next != w.goal && s.Prune != nil && s.Prune(next)) {
+			continue
+		}
+		_, _, to := moveCells(move)
+		if !fn(append(jumps, next)) || !w.jumps(next, to, append(jumps, next), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// count the solutions leading from the start to the goal board like CountSolutions, but
+// without enumerating them: the number of solutions of every board is remembered, so each
+// board is only looked at once. This is feasible even for the standard puzzle of the English
+// board, though its millions of boards take a few minutes
+func CountSolutionsMemoized(start uint64, goal uint64) uint64 {
+	return newSolutionCounter(goal).count(start)
+}
+
+// get the state of CountSolutionsMemoized for a goal, it can count the solutions of several
+// boards sharing the numbers remembered
+func newSolutionCounter(goal uint64) *solutionCounter {
+	c := &solutionCounter{
+		s:        NewSolver(),
+		goal:     goal,
+		goalPegs: PegCount(goal),
+		counts:   map[uint64]uint64{},
+	}
+	c.s.symmetries = c.s.searchSymmetries(goal, goal)
+	return c
+}
+
+// state of CountSolutionsMemoized
+type solutionCounter struct {
+	s        *Solver
+	goal     uint64
+	goalPegs int
+	// the number of solutions by the key of the board (see seenKey)
+	counts map[uint64]uint64
+}
+
+// count the solutions leading from the board to the goal
+func (c *solutionCounter) count(board uint64) uint64 {
+	if board == c.goal {
+		return 1
+	}
+	if PegCount(board) <= c.goalPegs || !c.s.Board.IsSolvable(board, c.goal) {
+		return 0
+	}
+	key := c.s.seenKey(board)
+	if count, found := c.counts[key]; found {
+		return count
+	}
+	var count uint64
+	for _, move := range c.s.Board.LegalMoves(board) {
+		next, _ := Apply(board, move)
+		count += c.count(next)
+	}
+	c.counts[key] = count
+	return count
+}
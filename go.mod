@@ -0,0 +1,3 @@
+module github.com/schur/solitaire
+
+go 1.22
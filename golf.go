@@ -0,0 +1,115 @@
+package solitaire
+
+// in peg golf a solution is better the fewer distinct pegs ever move. Pegs can not be told
+// apart on a board, so a peg is identified by its moves: the jumping peg stays the same peg
+// in its destination cell, and a peg that has not moved yet is new when it jumps
+
+// get the number of distinct pegs that move during a path
+func MovingPegCount(path []uint64) int {
+	count := 0
+	// the cells holding a peg that has already moved
+	var moved uint64
+	for _, move := range SolutionMoves(path) {
+		from, _, _ := moveCells(move)
+		if moved&(1<<from) == 0 {
+			count++
+		}
+		moved = movedAfter(moved, move)
+	}
+	return count
+}
+
+// update the cells holding pegs that have moved for a move: the jumping peg ends up in the
+// destination, the jumped over peg is removed
+func movedAfter(moved uint64, move Move) uint64 {
+	return moved&^move.before | move.after
+}
+
+// find the solution in which the fewest distinct pegs move by a forward depth first search
+// over all solutions. A path is cut off once it has as many moving pegs as the best solution
+// found so far, or if it reaches a board (with the same moved pegs) that an earlier path
+// reached with at most as many moving pegs
+// Note: like the other objectives looking at all solutions, this is only feasible for boards
+// with few pegs
+func (s *Solver) searchPegGolf(start uint64) {
+	w := golfSearch{
+		s:        s,
+		goal:     s.goal.Load(),
+		goalPegs: PegCount(s.goal.Load()),
+		reached:  map[golfState]int{},
+		dead:     map[uint64]bool{},
+		best:     PegCount(start) + 1,
+		path:     []uint64{start},
+	}
+	w.walk(start, 0, 0)
+}
+
+// a board during searchPegGolf together with the cells holding pegs that have moved
+type golfState struct {
+	board uint64
+	moved uint64
+}
+
+// state of searchPegGolf
+type golfSearch struct {
+	s        *Solver
+	goal     uint64
+	goalPegs int
+	// the fewest moving pegs with which a state was reached
+	reached map[golfState]int
+	// the boards from which the goal can not be reached
+	dead map[uint64]bool
+	// the moving pegs of the best solution found so far
+	best int
+	path []uint64
+}
+
+// continue the current path (ending in the board, with count moving pegs) towards the goal,
+// returns false if the goal can not be reached from the board
+func (w *golfSearch) walk(board uint64, moved uint64, count int) bool {
+	s := w.s
+	s.visit(board)
+	if s.nodes.Add(1)%contextCheckInterval == 0 && s.ctx.Err() != nil {
+		s.err = s.ctx.Err()
+	}
+	if s.err != nil {
+		return true
+	}
+	if board == w.goal {
+		if count < w.best {
+			w.best = count
+			s.Solution = append([]uint64(nil), w.path...)
+		}
+		return true
+	}
+	if PegCount(board) <= w.goalPegs || w.dead[board] {
+		return false
+	}
+	// the board may still lead to the goal, it is just not worth searching
+	state := golfState{board, moved}
+	if previous, found := w.reached[state]; count >= w.best || (found && previous <= count) {
+		return true
+	}
+	w.reached[state] = count
+	reachable := false
+	for _, move := range s.moves {
+		next, ok := Apply(board, move)
+		if !ok || !s.allows(board, move) || (next != w.goal && s.Prune != nil && s.Prune(next)) {
+			continue
+		}
+		nextCount := count
+		from, _, _ := moveCells(move)
+		if moved&(1<<from) == 0 {
+			nextCount++
+		}
+		w.path = append(w.path, next)
+		if w.walk(next, movedAfter(moved, move), nextCount) {
+			reachable = true
+		}
+		w.path = w.path[:len(w.path)-1]
+	}
+	if !reachable {
+		w.dead[board] = true
+	}
+	return reachable
+}
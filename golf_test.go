@@ -0,0 +1,43 @@
+package solitaire
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMovingPegCount(t *testing.T) {
+	// the fourth move jumps with the peg of the second one again
+	path, err := ReplayNotation(INITIAL_BOARD, englishSolution[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := MovingPegCount(path); count != 3 {
+		t.Errorf("MovingPegCount = %d, want 3", count)
+	}
+}
+
+// the peg golf objective finds a solution with the fewest moving pegs of all solutions
+func TestSolvePegGolf(t *testing.T) {
+	for _, moves := range []int{20, 22, 24} {
+		start := boardAfterMoves(t, moves)
+		fewest := -1
+		EnumerateSolutions(start, GOAL_BOARD, func(path []uint64) bool {
+			if count := MovingPegCount(path); fewest < 0 || count < fewest {
+				fewest = count
+			}
+			return true
+		})
+		solver := NewSolver()
+		solver.Objective = PegGolf
+		result, err := solver.Solve(context.Background(), start, GOAL_BOARD)
+		if err != nil {
+			t.Fatalf("after %d moves: %v", moves, err)
+		}
+		if err := VerifySolution(start, GOAL_BOARD, result.Path); err != nil {
+			t.Errorf("after %d moves: %v", moves, err)
+		}
+		if count := MovingPegCount(result.Path); count != fewest {
+			t.Errorf("after %d moves: the solution moves %d pegs, the fewest of all solutions are %d", moves, count, fewest)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package solitaire
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// write the graph of all boards reachable from the start board in the DOT format of
+// Graphviz: every node is a board (labelled with its diagram and peg count), every edge a
+// move. At most maxNodes boards are written, returns false if the graph had to be cut off
+// Note: this is only feasible for boards with few pegs, the state space of the
+// English board has millions of boards
+func WriteDOT(w io.Writer, start uint64, maxNodes int) (bool, error) {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "digraph solitaire {")
+	fmt.Fprintln(out, "\tnode [shape=box, fontname=\"monospace\"];")
+
+	// visit all boards in breadth first order, ids holds the node number of every board
+	ids := map[uint64]int{start: 0}
+	queue := []uint64{start}
+	complete := true
+	for len(queue) > 0 {
+		board := queue[0]
+		queue = queue[1:]
+		label := strings.Join(boardLines(board), "\\l") + "\\l" + fmt.Sprintf("%d pegs", PegCount(board))
+		fmt.Fprintf(out, "\tn%d [label=\"%s\"];\n", ids[board], label)
+		for _, move := range defaultBoard.Moves {
+			next, ok := Apply(board, move)
+			if !ok {
+				continue
+			}
+			if _, found := ids[next]; !found {
+				if len(ids) >= maxNodes {
+					complete = false
+					continue
+				}
+				ids[next] = len(ids)
+				queue = append(queue, next)
+			}
+			fmt.Fprintf(out, "\tn%d -> n%d [label=\"%s\"];\n", ids[board], ids[next], MoveString(move))
+		}
+	}
+	fmt.Fprintln(out, "}")
+	return complete, out.Flush()
+}
+
+// write the graph of the boards reachable from the start board to a file and warn if it is incomplete
+func writeGraph(path string, start uint64, maxNodes int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	complete, err := WriteDOT(file, start, maxNodes)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && !complete {
+		fmt.Fprintf(os.Stderr, "warning: more than %d reachable boards, the graph is incomplete\n", maxNodes)
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package solitaire
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+)
+
+// the layout of a board in an image: the 7 x 7 cells form a regular grid starting Margin
+// pixels from the left and top edge, with Spacing pixels from the center of one cell to
+// the next (0 to spread the grid evenly over the image)
+type ImageGrid struct {
+	Margin  int
+	Spacing int
+}
+
+// the share of the full brightness below which a cell center counts as a peg
+const pegBrightness = 0.5
+
+// read a board from a PNG image with the grid spread over the whole image (see ParsePNGGrid)
+func ParsePNG(r io.Reader) (uint64, error) {
+	return ParsePNGGrid(r, ImageGrid{})
+}
+
+// read a board from a PNG image of a grid of dark pegs and light holes on a light
+// background: the brightness of the pixel at the center of every cell tells whether it
+// holds a peg. Returns an error if the grid does not fit into the image or if a cell
+// outside of the board looks like a peg, which means the image does not show the board
+// in the expected layout
+func ParsePNGGrid(r io.Reader, grid ImageGrid) (uint64, error) {
+	img, err := png.Decode(r)
+	if err != nil {
+		return 0, err
+	}
+	bounds := img.Bounds()
+	spacing := grid.Spacing
+	if spacing == 0 {
+		spacing = (min(bounds.Dx(), bounds.Dy()) - 2*grid.Margin) / 7
+	}
+	if spacing <= 0 || grid.Margin < 0 || grid.Margin+7*spacing > min(bounds.Dx(), bounds.Dy()) {
+		return 0, fmt.Errorf("a grid of 7 x 7 cells with margin %d and spacing %d does not fit into the image of %d x %d pixels",
+			grid.Margin, spacing, bounds.Dx(), bounds.Dy())
+	}
+	var board uint64
+	for row := 0; row < 7; row++ {
+		for col := 0; col < 7; col++ {
+			x := bounds.Min.X + grid.Margin + col*spacing + spacing/2
+			y := bounds.Min.Y + grid.Margin + row*spacing + spacing/2
+			if brightness(img, x, y) >= pegBrightness {
+				continue
+			}
+			if !IsValidCell(row, col) {
+				return 0, fmt.Errorf("the image does not show a board: row %d, column %d is outside of the board but looks like a peg", row+1, col+1)
+			}
+			board = SetCell(board, row, col)
+		}
+	}
+	return board, nil
+}
+
+// get the brightness of a pixel between 0 (black) and 1 (white)
+func brightness(img image.Image, x int, y int) float64 {
+	r, g, b, _ := img.At(x, y).RGBA()
+	// the usual weights of the color channels for the perceived brightness
+	return (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 0xffff
+}
+
+// read a board from a PNG file (see ParsePNG)
+func readPNG(path string) (uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	board, err := ParsePNG(file)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", path, err)
+	}
+	return board, nil
+}
@@ -0,0 +1,207 @@
+package solitaire
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"strings"
+)
+
+// boards are read in the same format as they are printed: 7 lines with 7 characters,
+// "X" (or "x") is a peg, "0" (or "o", ".") an empty slot and a space a cell that is not
+// part of the board - trailing spaces may be omitted, and a "." may pad a cell that is not
+// part of the board as well (e.g. "..XXX.." for the top line of the English board). Several boards are separated by
+// blank lines or by lines of dashes (like "---"), so the output of the solver can be read back
+
+// format a board in the text format (without a trailing newline)
+func FormatBoard(board uint64) string {
+	return strings.Join(boardLines(board), "\n")
+}
+
+// get the 7 lines of a board of the selected variant in the text format (without trailing
+// spaces)
+func boardLines(board uint64) []string {
+	return defaultBoard.boardLines(board)
+}
+
+// get the 7 lines of a board in the text format (without trailing spaces)
+func (b *Board) boardLines(board uint64) []string {
+	lines := make([]string, 7)
+	for row := 0; row < 7; row++ {
+		line := make([]byte, 7)
+		for col := 0; col < 7; col++ {
+			var cell uint64 = 1 << coordToBit(row, col)
+			switch {
+			case (cell & b.ValidCells) == 0:
+				line[col] = ' '
+			case (cell & board) != 0:
+				line[col] = 'X'
+			default:
+				line[col] = '0'
+			}
+		}
+		lines[row] = strings.TrimRight(string(line), " ")
+	}
+	return lines
+}
+
+// parse a single board
+func ParseBoard(text string) (uint64, error) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	return parseBoardLines(lines)
+}
+
+// parse a board given as its 7 lines
+func parseBoardLines(lines []string) (uint64, error) {
+	if len(lines) != 7 {
+		return 0, fmt.Errorf("expected 7 lines, got %d", len(lines))
+	}
+	var board uint64
+	for row, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if len(line) > 7 {
+			return 0, fmt.Errorf("line %d: expected at most 7 cells, got %d", row+1, len(line))
+		}
+		for col := 0; col < 7; col++ {
+			var cell uint64 = 1 << (7*row + col)
+			validCell := (cell & defaultBoard.ValidCells) != 0
+			c := byte(' ')
+			if col < len(line) {
+				c = line[col]
+			}
+			switch {
+			case c == ' ' || (c == '.' && !validCell):
+				if validCell {
+					return 0, fmt.Errorf("line %d: cell %d is missing", row+1, col+1)
+				}
+			case !validCell:
+				return 0, fmt.Errorf("line %d: cell %d is not on the board", row+1, col+1)
+			case c == 'X' || c == 'x':
+				board |= cell
+			case c == '0' || c == 'o' || c == '.':
+			default:
+				return 0, fmt.Errorf("line %d: invalid character %q", row+1, c)
+			}
+		}
+	}
+	return board, nil
+}
+
+// parse a board given in a single line, e.g. for the command line: the 7 lines of the
+// text format are separated by ';' or '/'
+func ParseGrid(text string) (uint64, error) {
+	lines := strings.FieldsFunc(text, func(r rune) bool { return r == ';' || r == '/' })
+	return parseBoardLines(lines)
+}
+
+// parse a board given in a single line as the cells of the board in reading order (row by
+// row, from left to right) with the characters of the text format: either one character per
+// valid cell (33 for the English board), or one per cell of the 7 x 7 layout (49) with
+// spaces for the cells that are not part of the board
+func ParseCells(text string) (uint64, error) {
+	if len(text) == 49 {
+		lines := make([]string, 7)
+		for row := range lines {
+			lines[row] = text[7*row : 7*row+7]
+		}
+		return parseBoardLines(lines)
+	}
+	if valid := bits.OnesCount64(defaultBoard.ValidCells); len(text) != valid {
+		return 0, fmt.Errorf("expected %d or 49 cells, got %d", valid, len(text))
+	}
+	var board uint64
+	i := 0
+	for cell := 0; cell < 49; cell++ {
+		if !IsValidBit(cell) {
+			continue
+		}
+		switch c := text[i]; c {
+		case 'X', 'x':
+			board |= 1 << cell
+		case '0', 'o', '.':
+		default:
+			return 0, fmt.Errorf("cell %d: invalid character %q", i+1, c)
+		}
+		i++
+	}
+	return board, nil
+}
+
+// get a board given on the command line: the first board of a file in the format of
+// -boards, or the board itself in the format of -grid or of ParseCells
+func readBoardArg(arg string) (uint64, error) {
+	if _, err := os.Stat(arg); err == nil {
+		boards, err := readBoards(arg)
+		if err == nil && len(boards) == 0 {
+			err = fmt.Errorf("%s: no board found", arg)
+		}
+		if err != nil {
+			return 0, err
+		}
+		return boards[0], nil
+	}
+	if strings.ContainsAny(arg, "/;") {
+		return ParseGrid(arg)
+	}
+	return ParseCells(arg)
+}
+
+// check if a line separates two boards
+func isSeparator(line string) bool {
+	line = strings.TrimSpace(line)
+	return line == "" || (len(line) >= 3 && strings.Trim(line, "-") == "")
+}
+
+// parse all boards of a reader
+func ParseBoards(r io.Reader) ([]uint64, error) {
+	var boards []uint64
+	var lines []string
+	// parse the lines collected so far as the next board
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		board, err := parseBoardLines(lines)
+		if err != nil {
+			return fmt.Errorf("board %d: %v", len(boards)+1, err)
+		}
+		boards = append(boards, board)
+		lines = lines[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if isSeparator(line) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+// read all boards of a file
+func readBoards(path string) ([]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	boards, err := ParseBoards(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return boards, nil
+}
@@ -0,0 +1,43 @@
+package solitaire
+
+import "testing"
+
+// the parser must never panic, and every board it accepts must be read back the same from
+// its formatted text
+func FuzzParseBoard(f *testing.F) {
+	f.Add(FormatBoard(INITIAL_BOARD))
+	f.Add(FormatBoard(GOAL_BOARD) + "\n")
+	f.Add("  xxx\n  xox\nXXXXXXX\nXXX.XXX\nXXXXXXX\n  XXX\n  XXX")
+	// too few lines, a peg outside the board, a missing cell, an invalid character, a line
+	// too long
+	f.Add("  XXX\n  XXX\n")
+	f.Add("X XXX\n  XXX\nXXXXXXX\nXXX0XXX\nXXXXXXX\n  XXX\n  XXX")
+	f.Add("  XX\n  XXX\nXXXXXXX\nXXX0XXX\nXXXXXXX\n  XXX\n  XXX")
+	f.Add("  XXX\n  XXX\nXXXXXXX\nXXX?XXX\nXXXXXXX\n  XXX\n  XXX")
+	f.Add("  XXX\n  XXX\nXXXXXXXX\nXXX0XXX\nXXXXXXX\n  XXX\n  XXX")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, text string) {
+		board, err := ParseBoard(text)
+		if err != nil {
+			return
+		}
+		if board&^defaultBoard.ValidCells != 0 {
+			t.Fatalf("ParseBoard(%q) has pegs outside the board: %#x", text, board)
+		}
+		again, err := ParseBoard(FormatBoard(board))
+		if err != nil || again != board {
+			t.Fatalf("board %#x of %q is read back from its text as %#x, %v", board, text, again, err)
+		}
+	})
+}
+
+// a "." outside the board pads the line like a space, inside it is an empty slot
+func TestParseGridDots(t *testing.T) {
+	board, err := ParseGrid("..XXX..;..XXX..;XXXXXXX;XXX.XXX;XXXXXXX;..XXX..;..XXX..")
+	if err != nil || board != INITIAL_BOARD {
+		t.Errorf("got %#x, %v, want the start board %#x", board, err, INITIAL_BOARD)
+	}
+	if _, err := ParseGrid("..XXX..;..XXX..;XXXXXXX;XXX.XXX;XXXXXXX;..XXX..;..XXXX."); err == nil {
+		t.Error("a peg outside the board is accepted")
+	}
+}
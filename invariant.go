@@ -0,0 +1,110 @@
+package solitaire
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+)
+
+// the color invariant of peg solitaire: color the cells with three colors that cycle along
+// the rows and columns, once by (row + col) mod 3 and once by (row - col) mod 3. The three
+// cells of a move always have three different colors in both colorings, so every move
+// changes the peg count of each color by one, flipping the parity of all three counts.
+// Whether the three counts have the same parity is therefore never changed by a move,
+// and a board can only reach boards with the same invariant
+
+// get the color (0, 1 or 2) of a cell in the first coloring, (row + col) mod 3: the colors
+// cycle along the rows and columns, so cells of the same color form diagonals running from
+// the bottom left to the top right
+func CellColor(cell int) int {
+	row, col := bitToCoord(cell)
+	return (row + col) % 3
+}
+
+// get the color of a cell in the second coloring, (row - col) mod 3, the mirror image of
+// the first one
+func mirroredCellColor(cell int) int {
+	row, col := bitToCoord(cell)
+	return (row - col + 6) % 3
+}
+
+// check whether the three cells of every move have three different colors in both colorings
+func movesKeepColors(moves []Move) bool {
+	for _, move := range moves {
+		var colors, mirrored uint8
+		for cells := move.all; cells != 0; cells &= cells - 1 {
+			cell := bits.TrailingZeros64(cells)
+			colors |= 1 << CellColor(cell)
+			mirrored |= 1 << mirroredCellColor(cell)
+		}
+		if colors != 0b111 || mirrored != 0b111 {
+			return false
+		}
+	}
+	return true
+}
+
+// get the color invariant of a board: for both colorings two bits telling if the
+// peg counts of colors 0 and 1, and of colors 1 and 2 have a different parity
+func ColorInvariant(board uint64) uint8 {
+	var counts [2][3]int
+	for board != 0 {
+		cell := bits.TrailingZeros64(board)
+		board &= board - 1
+		counts[0][CellColor(cell)]++
+		counts[1][mirroredCellColor(cell)]++
+	}
+	var invariant uint8
+	for _, c := range counts {
+		invariant = invariant<<2 | uint8((c[0]+c[1])%2)<<1 | uint8((c[1]+c[2])%2)
+	}
+	return invariant
+}
+
+// quick check if the goal can be reached from the start board: false if the goal has more
+// pegs or a different color invariant (if the moves keep it), or if it is the empty board
+// (a jump always leaves the jumping peg on the board), so the search can be skipped
+// Note: true only means that these checks pass, the board may still be unsolvable
+func IsSolvable(start uint64, goal uint64) bool {
+	return defaultBoard.IsSolvable(start, goal)
+}
+
+// quick check if the goal can be reached from the start board by the moves of the board,
+// see IsSolvable
+func (b *Board) IsSolvable(start uint64, goal uint64) bool {
+	if goal == 0 {
+		return start == 0
+	}
+	return PegCount(start) >= PegCount(goal) && (!b.colorInvariantHolds || ColorInvariant(start) == ColorInvariant(goal))
+}
+
+// the cells in which the standard puzzle of the English board (starting with only the center
+// empty) can end with a single peg: d1, a4, d4, g4 and d7. The color invariant rules out all
+// other cells (a consequence of it is known as the rule of three: the last peg can only end
+// a multiple of three rows and columns away from the starting hole), and each of these five
+// can be reached
+var SolvableEndings uint64 = 1<<coordToBit(0, 3) | 1<<coordToBit(3, 0) | 1<<coordToBit(3, 3) |
+	1<<coordToBit(3, 6) | 1<<coordToBit(6, 3)
+
+// check for each of the valid cells whether the puzzle starting with only that cell empty
+// can be solved to GOAL_BOARD, and print the result - starts that break the color
+// invariant are rejected without searching
+func EnumerateSingleHoleStarts() {
+	solver := NewSolver()
+	for cell := 0; cell < 49; cell++ {
+		if !IsValidBit(cell) {
+			continue
+		}
+		start := FullBoardExcept(cell)
+		result := "solvable"
+		if !IsSolvable(start, GOAL_BOARD) {
+			result = "not solvable (color invariant)"
+		} else {
+			solver.SetOrder("random")
+			if _, err := solver.Solve(context.Background(), start, GOAL_BOARD); err != nil {
+				result = "not solvable"
+			}
+		}
+		fmt.Printf("%s: %s\n", cellName(cell), result)
+	}
+}
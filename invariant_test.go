@@ -0,0 +1,117 @@
+package solitaire
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// the empty board can not be reached, every search gives up on it without searching
+func TestEmptyGoal(t *testing.T) {
+	solver := NewSolver()
+	if _, err := solver.Solve(context.Background(), INITIAL_BOARD, 0); err != ErrNoSolution {
+		t.Errorf("Solve: got %v, want ErrNoSolution", err)
+	}
+	if nodes := solver.NodesVisited(); nodes != 0 {
+		t.Errorf("Solve visited %d boards", nodes)
+	}
+	if _, err := SolveBidirectional(context.Background(), INITIAL_BOARD, 0, 0); err != ErrNoSolution {
+		t.Errorf("SolveBidirectional: got %v, want ErrNoSolution", err)
+	}
+	if _, err := SolveAStar(context.Background(), INITIAL_BOARD, 0, nil); err != ErrNoSolution {
+		t.Errorf("SolveAStar: got %v, want ErrNoSolution", err)
+	}
+	if _, err := SolveParallel(context.Background(), INITIAL_BOARD, 0, 2, 1); err != ErrNoSolution {
+		t.Errorf("SolveParallel: got %v, want ErrNoSolution", err)
+	}
+	if count := CountSolutions(INITIAL_BOARD, 0, 0, nil); count != 0 {
+		t.Errorf("CountSolutions = %d, want 0", count)
+	}
+	if paths := MinLengthSolutions(INITIAL_BOARD, 0, 1); len(paths) != 0 {
+		t.Errorf("MinLengthSolutions gives %d solutions", len(paths))
+	}
+	// the empty board is its own solution
+	if path, err := Solve(0, 0); err != nil || len(path) != 1 {
+		t.Errorf("Solve(0, 0) = %v, %v, want the empty board alone", path, err)
+	}
+}
+
+// the cells the standard puzzle can end in are those a search reaches a single peg in, all
+// other cells are ruled out by the color invariant
+func TestSolvableEndings(t *testing.T) {
+	var endings uint64
+	for cell := 0; cell < 49; cell++ {
+		if !IsValidBit(cell) || !IsSolvable(INITIAL_BOARD, 1<<cell) {
+			continue
+		}
+		solver := NewSolver()
+		// a seed finding a solution for each of the endings quickly
+		solver.Rand = rand.New(rand.NewSource(5))
+		solver.SetOrder("random")
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		result, err := solver.Solve(ctx, INITIAL_BOARD, 1<<cell)
+		cancel()
+		if err != nil {
+			t.Fatalf("%s: %v", cellName(cell), err)
+		}
+		if err := VerifySolution(INITIAL_BOARD, 1<<cell, result.Path); err != nil {
+			t.Fatalf("%s: %v", cellName(cell), err)
+		}
+		endings |= 1 << cell
+	}
+	if endings != SolvableEndings {
+		t.Errorf("the puzzle ends in %s, SolvableEndings are %s", cellList(endings), cellList(SolvableEndings))
+	}
+}
+
+// get the names of the cells of a mask, for messages
+func cellList(cells uint64) []string {
+	var names []string
+	for cell := 0; cell < 49; cell++ {
+		if cells&(1<<cell) != 0 {
+			names = append(names, cellName(cell))
+		}
+	}
+	return names
+}
+
+// no move changes the color invariant
+func TestColorInvariant(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		board := random.Uint64() & defaultBoard.ValidCells
+		for _, move := range LegalMoves(board) {
+			next, _ := Apply(board, move)
+			if ColorInvariant(next) != ColorInvariant(board) {
+				t.Fatalf("%s on %#x changes the color invariant", MoveDescription(move), board)
+			}
+		}
+	}
+}
+
+// a goal breaking the color invariant is rejected without searching, the start board as the
+// goal is solved without a move
+func TestIsSolvable(t *testing.T) {
+	c3 := uint64(1) << coordToBit(2, 2)
+	if IsSolvable(INITIAL_BOARD, c3) {
+		t.Errorf("a single peg in c3 passes the color invariant")
+	}
+	if IsSolvable(GOAL_BOARD, INITIAL_BOARD) {
+		t.Errorf("a goal with more pegs than the start passes")
+	}
+	if !IsSolvable(INITIAL_BOARD, GOAL_BOARD) || !IsSolvable(INITIAL_BOARD, INITIAL_BOARD) {
+		t.Errorf("the standard puzzle does not pass")
+	}
+	solver := NewSolver()
+	if _, err := solver.Solve(context.Background(), INITIAL_BOARD, c3); err != ErrNoSolution {
+		t.Errorf("Solve to c3: got %v, want ErrNoSolution", err)
+	}
+	if nodes := solver.NodesVisited(); nodes != 0 {
+		t.Errorf("Solve to c3 visited %d boards", nodes)
+	}
+	result, err := solver.Solve(context.Background(), INITIAL_BOARD, INITIAL_BOARD)
+	if err != nil || len(result.Path) != 1 || result.Moves != 0 {
+		t.Errorf("Solve to the start board = %d boards, %v, want the start board alone", len(result.Path), err)
+	}
+}
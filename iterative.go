@@ -0,0 +1,68 @@
+package solitaire
+
+// a board on the path of searchIterative with the moves to try on it and the index of the
+// next one
+type searchFrame struct {
+	board uint64
+	moves []Move
+	next  int
+}
+
+// do the reverse search of search without recursion: the boards from the goal to the
+// currently visited board are kept on a stack, every board with the position in its moves,
+// so the boards are visited in the same order as by search
+func (s *Solver) searchIterative(goal uint64) []uint64 {
+	var stack []searchFrame
+	push := func(board uint64) bool {
+		moves, ok := s.enter(board)
+		if !ok {
+			return false
+		}
+		if s.FailureDiagnostics {
+			s.pushPath(board)
+		}
+		stack = append(stack, searchFrame{board: board, moves: moves})
+		return true
+	}
+	if !push(goal) {
+		return nil
+	}
+	for len(stack) > 0 {
+		frame := &stack[len(stack)-1]
+		if frame.next == len(frame.moves) {
+			// all moves are tried, back to the board before
+			stack = stack[:len(stack)-1]
+			if s.FailureDiagnostics {
+				s.popPath()
+			}
+			if len(stack) > 0 {
+				s.failed(frame.board)
+			}
+			continue
+		}
+		board, move := frame.board, frame.moves[frame.next]
+		frame.next++
+		if (move.before&board) == 0 && (move.after&board) != 0 {
+			newBoard := board ^ move.all
+			if !s.isNewBoard(board, newBoard, move) {
+				continue
+			}
+			if newBoard == s.target {
+				// the path in playing order is the stack from the top down
+				path := make([]uint64, 0, s.targetPegs)
+				path = append(path, newBoard)
+				for i := len(stack) - 1; i >= 0; i-- {
+					path = append(path, stack[i].board)
+				}
+				if s.FailureDiagnostics {
+					s.currentPath = s.currentPath[:0]
+				}
+				return path
+			}
+			if s.isCandidate(newBoard) && !push(newBoard) {
+				s.failed(newBoard)
+			}
+		}
+	}
+	return nil
+}
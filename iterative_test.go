@@ -0,0 +1,54 @@
+package solitaire
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// the iterative search visits the same boards as the recursive one and finds the same
+// solution, also when it fails
+func TestSearchIterative(t *testing.T) {
+	starts := []uint64{boardAfterMoves(t, 21), boardAfterMoves(t, 22)}
+	// a single peg in d7 can not be reached from these boards, though the color invariant
+	// allows it
+	goals := []uint64{GOAL_BOARD, 1 << coordToBit(6, 3)}
+	for seed := int64(1); seed <= 4; seed++ {
+		for _, start := range starts {
+			for _, goal := range goals {
+				var results [2]*Solver
+				for i, iterative := range []bool{false, true} {
+					solver := NewSolver()
+					solver.Rand = rand.New(rand.NewSource(seed))
+					solver.SetOrder("random")
+					solver.FailureDiagnostics = true
+					solver.Iterative = iterative
+					solver.Solve(context.Background(), start, goal)
+					results[i] = solver
+				}
+				recursive, iterative := results[0], results[1]
+				if !slices.Equal(recursive.Solution, iterative.Solution) {
+					t.Errorf("seed %d: the iterative search finds another solution", seed)
+				}
+				if recursive.NodesVisited() != iterative.NodesVisited() {
+					t.Errorf("seed %d: the iterative search visits %d boards, the recursive one %d",
+						seed, iterative.NodesVisited(), recursive.NodesVisited())
+				}
+				if !slices.Equal(recursive.DeepestPath, iterative.DeepestPath) {
+					t.Errorf("seed %d: the iterative search has another deepest path", seed)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSolveRecursive(b *testing.B) {
+	b.ReportAllocs()
+	benchmarkSolve(b, func(s *Solver) { s.Iterative = false })
+}
+
+func BenchmarkSolveIterative(b *testing.B) {
+	b.ReportAllocs()
+	benchmarkSolve(b, func(s *Solver) { s.Iterative = true })
+}
@@ -0,0 +1,37 @@
+package solitaire
+
+// a known solution of the standard puzzle of the English board (from the full board with d4
+// empty to a single peg in d4), in from-to notation - it is the solution of SolveCanonical,
+// recorded so it can be played without searching
+var englishSolution = []string{
+	"b4-d4", "c6-c4", "a5-c5", "c4-c6", "c7-c5", "d5-b5", "f5-d5", "e7-e5",
+	"d5-f5", "d7-d5", "g5-e5", "e4-e6", "a3-a5", "e2-e4", "g3-e3", "e4-e2",
+	"a5-c5", "c5-e5", "e6-e4", "e1-e3", "e4-e2", "c2-c4", "c1-e1", "e1-e3",
+	"d4-b4", "e3-c3", "b3-d3", "d2-d4", "g4-e4", "e4-c4", "b4-d4",
+}
+
+// get a solution without searching, from the known solution of the English board: if the
+// start board is one of its boards, or a rotation/reflection of one with the goal board
+// transformed alike, the rest of the (transformed) known solution leads to the goal.
+// Returns false for all other boards and for the other variants, which have to be searched
+func KnownSolution(start uint64, goal uint64) ([]uint64, bool) {
+	english := Variants["english"]
+	if defaultBoard.ValidCells != english.ValidCells {
+		return nil, false
+	}
+	known, err := ReplayNotation(english.DefaultStart, englishSolution)
+	if err != nil {
+		return nil, false
+	}
+	for t := Identity; t <= ReflectAntiDiagonal; t++ {
+		if TransformBoard(english.DefaultGoal, t) != goal {
+			continue
+		}
+		// a board with k pegs can only be the board after the moves removing the others
+		i := len(known) - PegCount(start)
+		if i >= 0 && i < len(known) && TransformBoard(known[i], t) == start {
+			return TransformSolution(known[i:], t), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,81 @@
+package solitaire
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// a completed challenge as stored in the leaderboard file
+type ChallengeRecord struct {
+	Date time.Time `json:"date"`
+	// the start board of the puzzle
+	Puzzle string `json:"puzzle"`
+	Moves  int    `json:"moves"`
+	Hints  int    `json:"hints"`
+	// the time needed and the time including the penalty of the hints
+	Time  time.Duration `json:"time"`
+	Total time.Duration `json:"total"`
+}
+
+// the leaderboard file used if none is given: a file in the home directory
+func defaultLeaderboardFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".solitaire-leaderboard.json"
+	}
+	return filepath.Join(home, ".solitaire-leaderboard.json")
+}
+
+// read the records of a leaderboard file, a missing file has no records
+// returns the records read so far and an error if the file can not be read or parsed
+func LoadLeaderboard(path string) ([]ChallengeRecord, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []ChallengeRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return records, nil
+}
+
+// add a record to a leaderboard file - a file that can not be parsed is started fresh
+// (after a warning on stderr), so a corrupt file never stops the records from being saved
+func addToLeaderboard(path string, record ChallengeRecord) error {
+	records, err := LoadLeaderboard(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: starting a new leaderboard: %v\n", err)
+		records = nil
+	}
+	data, err := json.MarshalIndent(append(records, record), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// print the records of a leaderboard, the fastest (by the total time) first
+func PrintLeaderboard(records []ChallengeRecord) {
+	if len(records) == 0 {
+		fmt.Println("no challenges completed yet")
+		return
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Total < records[j].Total
+	})
+	fmt.Printf("%4s  %-10s  %10s  %10s  %5s  %5s  %s\n", "rank", "date", "total", "time", "moves", "hints", "puzzle")
+	for i, record := range records {
+		fmt.Printf("%4d  %-10s  %10v  %10v  %5d  %5d  %s\n", i+1, record.Date.Format("2006-01-02"),
+			record.Total.Round(time.Millisecond), record.Time.Round(time.Millisecond), record.Moves, record.Hints, record.Puzzle)
+	}
+}
@@ -0,0 +1,33 @@
+package solitaire
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// write a solution as a Markdown document, e.g. for an issue or a wiki page: every board is
+// a fenced code block in the current board style (see SetBoardStyle), headed by the number
+// and the notation of the move leading to it
+func WriteMarkdown(w io.Writer, path []uint64) error {
+	if len(path) == 0 {
+		return nil
+	}
+	var doc strings.Builder
+	doc.WriteString("# Peg solitaire solution\n\n")
+	fmt.Fprintf(&doc, "%d moves, %d peg(s) remaining.\n", len(path)-1, PegCount(path[len(path)-1]))
+	for step, board := range path {
+		if step == 0 {
+			doc.WriteString("\n## Start\n\n")
+		} else {
+			fmt.Fprintf(&doc, "\n## Move %d: %s\n\n", step, MoveString(moveBetween(path[step-1], board)))
+		}
+		doc.WriteString("```\n")
+		for _, line := range styledBoardLines(board) {
+			doc.WriteString(line + "\n")
+		}
+		doc.WriteString("```\n")
+	}
+	_, err := io.WriteString(w, doc.String())
+	return err
+}
@@ -0,0 +1,41 @@
+package solitaire
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// write the model of the board in a line based text format for external tools:
+//
+//	valid <mask>            the valid cells (bit 7*row+col is set for every slot)
+//	start <mask>            the start board
+//	goal <mask>             the goal board
+//	moves <n>               the number of moves, followed by one line per move:
+//	<i> <from> <over> <to> <from-row>,<from-col> <over-row>,<over-col> <to-row>,<to-col> <after> <before> <all>
+//
+// masks are hexadecimal, cells are bit indices, rows and columns start with 0 at the top
+// left (as printed) and after, before and all are the masks of the Move struct. Lines
+// starting with "#" are comments
+func (b *Board) DumpModel(w io.Writer, start uint64, goal uint64) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "# peg solitaire model, board layout:")
+	for _, line := range b.boardLines(b.ValidCells) {
+		fmt.Fprintln(out, "#", strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(out, "valid %#x\n", b.ValidCells)
+	fmt.Fprintf(out, "start %#x\n", start)
+	fmt.Fprintf(out, "goal %#x\n", goal)
+	fmt.Fprintf(out, "moves %d\n", len(b.Moves))
+	for i, move := range b.Moves {
+		from, over, to := moveCells(move)
+		fmt.Fprintf(out, "%d %d %d %d", i, from, over, to)
+		for _, cell := range []int{from, over, to} {
+			row, col := bitToCoord(cell)
+			fmt.Fprintf(out, " %d,%d", row, col)
+		}
+		fmt.Fprintf(out, " %#x %#x %#x\n", move.after, move.before, move.all)
+	}
+	return out.Flush()
+}
@@ -0,0 +1,172 @@
+package solitaire
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// moves are written in from-to notation, e.g. "d2-d4" moves the peg in d2 over d3 into d4
+// columns are labelled "a" to "g" from left to right and rows "1" to "7" from top to bottom
+// (in the orientation used by PrintSolution), so the center slot is "d4"
+
+// convert a slot name like "d4" into its bit index on the board
+func parseCell(name string) (int, error) {
+	if len(name) != 2 || name[0] < 'a' || name[0] > 'g' || name[1] < '1' || name[1] > '7' {
+		return 0, fmt.Errorf("invalid cell %q", name)
+	}
+	col := int(name[0] - 'a')
+	row := int(name[1] - '1')
+	cell := 7*row + col
+	if !IsValidBit(cell) {
+		return 0, fmt.Errorf("cell %q is not on the board", name)
+	}
+	return cell, nil
+}
+
+// parse a comma separated list of cell names (e.g. "c1,e1") into a mask of these cells
+func parseCells(names string) (uint64, error) {
+	var cells uint64
+	for _, name := range strings.Split(names, ",") {
+		cell, err := parseCell(strings.TrimSpace(name))
+		if err != nil {
+			return 0, err
+		}
+		cells |= 1 << cell
+	}
+	return cells, nil
+}
+
+// get the name of a cell (bit index), e.g. "d4" for the center
+func cellName(cell int) string {
+	row, col := bitToCoord(cell)
+	return string(rune('a'+col)) + string(rune('1'+row))
+}
+
+// get the number of a cell (bit index) when the valid cells are numbered from 1 in reading
+// order, e.g. 1 to 33 on the English board with 17 for the center
+func (b *Board) holeNumber(cell int) int {
+	return bits.OnesCount64(b.ValidCells&(1<<cell-1)) + 1
+}
+
+// get the from-to notation of a move
+func MoveString(move Move) string {
+	from, _, to := moveCells(move)
+	return cellName(from) + "-" + cellName(to)
+}
+
+// describe the three roles of the cells of a move in words, e.g. "d2 jumps over d3 into d4"
+func MoveDescription(move Move) string {
+	from, over, to := moveCells(move)
+	return cellName(from) + " jumps over " + cellName(over) + " into " + cellName(to)
+}
+
+// list the cells that changed between two boards, e.g. "-d2 -d3 +d4" for the move d2-d4
+// (emptied cells are prefixed with "-", filled ones with "+", both in ascending order)
+// returns an error if the boards do not differ by exactly one move
+func DiffString(before uint64, after uint64) (string, error) {
+	move := moveBetween(before, after)
+	if err := ValidateMove(move); err != nil {
+		return "", fmt.Errorf("boards %#x and %#x do not differ by a single move: %v", before, after, err)
+	}
+	var changes []string
+	for cell := 0; cell < 49; cell++ {
+		if move.before&(1<<cell) != 0 {
+			changes = append(changes, "-"+cellName(cell))
+		}
+	}
+	changes = append(changes, "+"+cellName(bits.TrailingZeros64(move.after)))
+	return strings.Join(changes, " "), nil
+}
+
+// get the cells (bit indices) of a move: the moved peg, the jumped over peg and the destination
+func moveCells(move Move) (int, int, int) {
+	// the jumped over peg is always in the middle of the three cells. The lines wrapping
+	// around the edges and the diagonals never have the shape of another line, so the middle
+	// is the same on every board
+	over, _ := lineMiddle(move.all, true, true)
+	from := bits.TrailingZeros64(move.before &^ (1 << over))
+	to := bits.TrailingZeros64(move.after)
+	return from, over, to
+}
+
+// parse a move in from-to notation into the corresponding Move
+func ParseMove(notation string) (Move, error) {
+	var move Move
+	from, to, found := strings.Cut(strings.TrimSpace(notation), "-")
+	if !found {
+		return move, fmt.Errorf("invalid move %q: expected from-to notation", notation)
+	}
+	fromCell, err := parseCell(from)
+	if err != nil {
+		return move, fmt.Errorf("invalid move %q: %v", notation, err)
+	}
+	toCell, err := parseCell(to)
+	if err != nil {
+		return move, fmt.Errorf("invalid move %q: %v", notation, err)
+	}
+	// the moves wrapping around a torus (or going along a diagonal) are not two apart in a
+	// row or a column, so look the move up by its from and to cells
+	for _, move := range defaultBoard.Moves {
+		if moveFrom, _, moveTo := moveCells(move); moveFrom == fromCell && moveTo == toCell {
+			return move, nil
+		}
+	}
+	// the two slots have to be two apart in the same row or the same column
+	fromRow, fromCol := fromCell/7, fromCell%7
+	toRow, toCol := toCell/7, toCell%7
+	if !(fromRow == toRow && (fromCol-toCol == 2 || toCol-fromCol == 2)) &&
+		!(fromCol == toCol && (fromRow-toRow == 2 || toRow-fromRow == 2)) {
+		return move, fmt.Errorf("invalid move %q: slots are not two apart in a line", notation)
+	}
+	if !IsValidBit((fromCell + toCell) / 2) {
+		return move, fmt.Errorf("invalid move %q: jumped slot is not on the board", notation)
+	}
+	return move, fmt.Errorf("invalid move %q: not a move of the board", notation)
+}
+
+// replay a list of moves in from-to notation, starting from the given board
+// returns the sequence of boards (starting with "start"), or an error naming the
+// first move that can not be parsed or is not legal together with the board at that
+// point - in that case the boards up to the failing move are returned as well
+func ReplayNotation(start uint64, moves []string) ([]uint64, error) {
+	boards := make([]uint64, 0, len(moves)+1)
+	boards = append(boards, start)
+	board := start
+	for i, notation := range moves {
+		move, err := ParseMove(notation)
+		if err != nil {
+			return boards, fmt.Errorf("move %d: %v (board %#x)", i+1, err, board)
+		}
+		next, ok := Apply(board, move)
+		if !ok {
+			return boards, fmt.Errorf("move %d: %q is not legal on board %#x", i+1, notation, board)
+		}
+		board = next
+		boards = append(boards, board)
+	}
+	return boards, nil
+}
+
+// print the moves of a solution in from-to notation, one move per line
+func PrintMoves(solution []uint64) {
+	for _, move := range SolutionMoves(solution) {
+		fmt.Println(MoveString(move))
+	}
+}
+
+// write the moves of a solution as CSV: one row per move with the bit indices of the
+// moved peg, the jumped over peg and the destination plus the move in from-to notation
+func WriteCSV(w io.Writer, solution []uint64) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"step", "from", "over", "to", "move"})
+	for i, move := range SolutionMoves(solution) {
+		from, over, to := moveCells(move)
+		out.Write([]string{strconv.Itoa(i + 1), strconv.Itoa(from), strconv.Itoa(over), strconv.Itoa(to), MoveString(move)})
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,61 @@
+package solitaire
+
+import "testing"
+
+func TestParseMove(t *testing.T) {
+	tests := []struct {
+		notation string
+		// the cells of the expected move, ok is false if the notation has to be rejected
+		from, over, to string
+		ok             bool
+	}{
+		{"d2-d4", "d2", "d3", "d4", true},
+		{"b4-d4", "b4", "c4", "d4", true},
+		{" f4-d4 ", "f4", "e4", "d4", true},
+		{"d6-d4", "d6", "d5", "d4", true},
+		// adjacent cells
+		{"d2-d3", "", "", "", false},
+		{"d3-d2", "", "", "", false},
+		{"c4-d4", "", "", "", false},
+		// not two apart in a line
+		{"d1-d4", "", "", "", false},
+		{"c3-e5", "", "", "", false},
+		{"d4-d4", "", "", "", false},
+		// not in from-to notation or not on the board
+		{"d2d4", "", "", "", false},
+		{"a1-c1", "", "", "", false},
+		{"d2-d9", "", "", "", false},
+		{"", "", "", "", false},
+	}
+	for _, test := range tests {
+		move, err := ParseMove(test.notation)
+		if !test.ok {
+			if err == nil {
+				t.Errorf("ParseMove(%q) = %s, want an error", test.notation, MoveDescription(move))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMove(%q) failed: %v", test.notation, err)
+			continue
+		}
+		from, over, to := moveCells(move)
+		if cellName(from) != test.from || cellName(over) != test.over || cellName(to) != test.to {
+			t.Errorf("ParseMove(%q) = %s, want %s jumps over %s into %s",
+				test.notation, MoveDescription(move), test.from, test.over, test.to)
+		}
+		if err := ValidateMove(move); err != nil {
+			t.Errorf("ParseMove(%q) gives an invalid move: %v", test.notation, err)
+		}
+	}
+}
+
+// every move of the board is parsed back from its own notation
+func TestParseMoveString(t *testing.T) {
+	for _, move := range defaultBoard.Moves {
+		parsed, err := ParseMove(MoveString(move))
+		if err != nil || parsed != move {
+			t.Errorf("ParseMove(%q) = %v, %v, want %v", MoveString(move), parsed, err, move)
+		}
+	}
+}
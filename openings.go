@@ -0,0 +1,48 @@
+package solitaire
+
+import (
+	"fmt"
+	"sort"
+)
+
+// the number of solutions starting with an opening move
+type OpeningCount struct {
+	Move      Move
+	Solutions uint64
+}
+
+// count the solutions (leading from the start to the goal board) for every legal first move,
+// sorted by the number of solutions, the hardest opening (with the fewest solutions) first
+// and the openings without a solution last. Openings that are rotations/reflections of each
+// other (e.g. all four openings of the standard puzzle) have the same count
+// Note: the solutions are counted like CountSolutionsMemoized, with the numbers remembered
+// for all openings at once, which takes a few minutes for the standard puzzle
+func SolutionsByFirstMove(start uint64, goal uint64) []OpeningCount {
+	var counts []OpeningCount
+	counter := newSolutionCounter(goal)
+	for _, move := range LegalMoves(start) {
+		next, _ := Apply(start, move)
+		counts = append(counts, OpeningCount{move, counter.count(next)})
+	}
+	sort.SliceStable(counts, func(i, j int) bool {
+		// 0 - 1 wraps around to the largest count
+		return counts[i].Solutions-1 < counts[j].Solutions-1
+	})
+	return counts
+}
+
+// print the opening moves with their number of solutions, the hardest first
+func PrintOpenings(start uint64, goal uint64) {
+	counts := SolutionsByFirstMove(start, goal)
+	if len(counts) == 0 {
+		fmt.Println("no legal opening move")
+		return
+	}
+	for _, count := range counts {
+		if count.Solutions == 0 {
+			fmt.Printf("%s: no solution\n", MoveString(count.Move))
+		} else {
+			fmt.Printf("%s: %d solutions\n", MoveString(count.Move), count.Solutions)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package solitaire
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"slices"
+)
+
+// the order in which the search tries the moves has a huge influence on the run time
+
+// compare two moves by the Manhattan distance of their destination slot (the slot
+// of the "after" peg) to the center of the board - moves closer to the center come first
+// (usable with slices.SortFunc)
+func OrderTowardCenter(a Move, b Move) int {
+	center := CenterCell()
+	return centerDistance(a, center) - centerDistance(b, center)
+}
+
+// the Manhattan distance of the destination slot of a move to the center cell
+func centerDistance(move Move, center int) int {
+	row, col := bitToCoord(bits.TrailingZeros64(move.after))
+	centerRow, centerCol := bitToCoord(center)
+	return abs(row-centerRow) + abs(col-centerCol)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// bring the moves of the solver into the given order:
+// - "random" shuffles the moves (with Solver.Rand if set)
+// - "center" tries the moves ending closest to the center of the board first
+// - "generated" keeps the order in which the moves were generated
+func (s *Solver) SetOrder(order string) error {
+	moves := append(s.Moves[:0], s.Board.Moves...)
+	switch order {
+	case "random":
+		swap := func(i, j int) { moves[i], moves[j] = moves[j], moves[i] }
+		if s.Rand != nil {
+			s.Rand.Shuffle(len(moves), swap)
+		} else {
+			rand.Shuffle(len(moves), swap)
+		}
+	case "center":
+		center := s.Board.CenterCell()
+		slices.SortStableFunc(moves, func(a Move, b Move) int {
+			return centerDistance(a, center) - centerDistance(b, center)
+		})
+	case "generated":
+	default:
+		return fmt.Errorf("unknown move order %q", order)
+	}
+	s.Moves = moves
+	return nil
+}
+
+// reorder the current moves of the solver by a comparison function (see OrderTowardCenter),
+// moves that compare equal keep their order, so orderings can be combined by applying them
+// one after the other
+func (s *Solver) SetOrdering(compare func(a Move, b Move) int) {
+	slices.SortStableFunc(s.Moves, compare)
+}
+
+// sort the moves of the solver once by descending score, for scores that do not depend
+// on the board this is much cheaper than setting Solver.Score
+func (s *Solver) SortMoves(score func(move Move) int) {
+	slices.SortStableFunc(s.Moves, func(a Move, b Move) int {
+		return score(b) - score(a)
+	})
+}
+
+// get the applicable moves for a board ordered by descending score
+// Note: this allocates and sorts a slice for every visited board, which makes the search
+// several times slower than with a fixed move order
+func (s *Solver) scoredMoves(board uint64) []Move {
+	type scoredMove struct {
+		move  Move
+		score int
+	}
+	scored := make([]scoredMove, 0, 16)
+	for _, move := range s.moves {
+		if (move.before&board) == 0 && (move.after&board) != 0 {
+			scored = append(scored, scoredMove{move, s.Score(move, board)})
+		}
+	}
+	slices.SortStableFunc(scored, func(a scoredMove, b scoredMove) int {
+		return b.score - a.score
+	})
+	moves := make([]Move, len(scored))
+	for i := range scored {
+		moves[i] = scored[i].move
+	}
+	return moves
+}
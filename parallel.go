@@ -0,0 +1,188 @@
+package solitaire
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// find a solution with a pool of workers searching in parallel (one per CPU if workers is
+// not positive). The first few reverse moves from the goal are expanded into a queue of
+// tasks, every task is a board together with the moves leading from it to the goal. Since
+// there are many more tasks than workers, a worker that finished a small subtree simply
+// takes the next task while others are still busy with large ones. Without a seed the first
+// solution found cancels all other workers. With a seed (not 0) every task shuffles its
+// moves with the seed plus its index, and the solution of the first task having one is
+// returned: only the tasks after it are canceled, so the result does not depend on which
+// worker happens to be faster. The workers share the boards they failed on (see deadBoards)
+func SolveParallel(ctx context.Context, start uint64, goal uint64, workers int, seed int64) ([]uint64, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if start == goal {
+		return []uint64{start}, nil
+	}
+	if !IsSolvable(start, goal) {
+		return nil, ErrNoSolution
+	}
+	tasks, solution := parallelTasks(start, goal, 8*workers)
+	if solution != nil {
+		return solution, nil
+	}
+	if len(tasks) == 0 {
+		return nil, ErrNoSolution
+	}
+
+	search, cancel := context.WithCancel(ctx)
+	defer cancel()
+	queue := make(chan int, len(tasks))
+	for i := range tasks {
+		queue <- i
+	}
+	close(queue)
+	// the index of the first task with a solution found so far and the cancel functions of
+	// the running tasks
+	var mu sync.Mutex
+	best := len(tasks)
+	running := map[int]context.CancelFunc{}
+	dead := newDeadBoards(NewSolver().searchSymmetries(start, start))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			solver := NewSolver()
+			solver.SetOrder("random")
+			solver.dead = dead
+			for i := range queue {
+				mu.Lock()
+				if i > best {
+					mu.Unlock()
+					continue
+				}
+				taskCtx, cancelTask := context.WithCancel(search)
+				running[i] = cancelTask
+				mu.Unlock()
+				if seed != 0 {
+					solver.Rand = rand.New(rand.NewSource(seed + int64(i)))
+					solver.SetOrder("random")
+				}
+				// the task starts with the board the worker has to reach the start board from
+				_, err := solver.Solve(taskCtx, start, tasks[i][0])
+				mu.Lock()
+				delete(running, i)
+				cancelTask()
+				if err == nil && i < best {
+					best = i
+					solution = append(append([]uint64(nil), solver.Solution...), tasks[i][1:]...)
+					if seed == 0 {
+						cancel()
+					}
+					for j, cancelTask := range running {
+						if j > i {
+							cancelTask()
+						}
+					}
+				}
+				mu.Unlock()
+				if search.Err() != nil {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if solution != nil {
+		return solution, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ErrNoSolution
+}
+
+// expand the reverse moves from the goal board breadth first until there are at least
+// count distinct boards: returns for every board the path from it to the goal, or a
+// complete solution if the start board is reached while expanding
+func parallelTasks(start uint64, goal uint64, count int) ([][]uint64, []uint64) {
+	startPegs := PegCount(start)
+	tasks := [][]uint64{{goal}}
+	for len(tasks) < count {
+		var next [][]uint64
+		seen := map[uint64]bool{}
+		for _, task := range tasks {
+			for _, move := range defaultBoard.Moves {
+				board, ok := Undo(task[0], move)
+				if !ok || seen[board] {
+					continue
+				}
+				seen[board] = true
+				path := append([]uint64{board}, task...)
+				if board == start {
+					return nil, path
+				}
+				// every reverse move adds a peg, boards with as many pegs as the start are dead ends
+				if PegCount(board) < startPegs {
+					next = append(next, path)
+				}
+			}
+		}
+		tasks = next
+		if len(tasks) == 0 {
+			break
+		}
+	}
+	return tasks, nil
+}
+
+// deadBoards has 1 << deadShardBits shards, each with its own lock so the workers rarely
+// wait for each other
+const deadShardBits = 6
+
+// boards that can not be reached from the start board, shared by the workers of
+// SolveParallel. Whether the reverse search from a board finds the start does not depend on
+// the goal of the task, so a board one task failed on is skipped by all others. This only
+// saves time: the skipped boards can not lead to a solution, so every task still finds the
+// same solution (for a given order of the moves)
+type deadBoards struct {
+	// boards that are transforms of each other under the symmetries of the start board can
+	// both be reached or both not, so they share a key
+	symmetries []Transform
+	shards     [1 << deadShardBits]struct {
+		mu     sync.RWMutex
+		boards map[uint64]bool
+	}
+}
+
+// create an empty set of dead boards for a start board with the given symmetries
+func newDeadBoards(symmetries []Transform) *deadBoards {
+	d := &deadBoards{symmetries: symmetries}
+	for i := range d.shards {
+		d.shards[i].boards = map[uint64]bool{}
+	}
+	return d
+}
+
+// check whether a board is known to be unreachable from the start board
+func (d *deadBoards) contains(board uint64) bool {
+	key := symmetricKey(board, d.symmetries)
+	shard := &d.shards[d.shardIndex(key)]
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	return shard.boards[key]
+}
+
+// remember that a board can not be reached from the start board
+func (d *deadBoards) add(board uint64) {
+	key := symmetricKey(board, d.symmetries)
+	shard := &d.shards[d.shardIndex(key)]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	shard.boards[key] = true
+}
+
+// get the shard of a key from the top bits of a multiplicative hash
+func (d *deadBoards) shardIndex(key uint64) int {
+	return int(key * 0x9e3779b97f4a7c15 >> (64 - deadShardBits))
+}
@@ -0,0 +1,101 @@
+package solitaire
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// get the board after the first moves of the known solution of the English board, a start a
+// parallel search solves quickly
+func boardAfterMoves(tb testing.TB, moves int) uint64 {
+	tb.Helper()
+	path, err := ReplayNotation(INITIAL_BOARD, englishSolution[:moves])
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return path[len(path)-1]
+}
+
+func TestSolveParallelSeed(t *testing.T) {
+	start := boardAfterMoves(t, 18)
+	first, err := SolveParallel(context.Background(), start, GOAL_BOARD, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySolution(start, GOAL_BOARD, first); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		path, err := SolveParallel(context.Background(), start, GOAL_BOARD, 4, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(path, first) {
+			t.Fatalf("run %d with the same seed gives another solution", i+2)
+		}
+	}
+}
+
+// the shared dead boards must not change the solution of a task: every task solved on its own
+// gives the same solution as in the parallel search
+func TestDeadBoardsKeepSolutions(t *testing.T) {
+	start := boardAfterMoves(t, 21)
+	tasks, _ := parallelTasks(start, GOAL_BOARD, 16)
+	dead := newDeadBoards(NewSolver().searchSymmetries(start, start))
+	for i, task := range tasks {
+		var paths [2][]uint64
+		for j, shared := range []*deadBoards{nil, dead} {
+			solver := NewSolver()
+			solver.dead = shared
+			solver.SetOrder("generated")
+			if _, err := solver.Solve(context.Background(), start, task[0]); err == nil {
+				paths[j] = solver.Solution
+			}
+		}
+		if !slices.Equal(paths[0], paths[1]) {
+			t.Errorf("task %d gives another solution with the dead boards of the tasks before", i)
+		}
+	}
+}
+
+func TestSolveParallelUnsolvable(t *testing.T) {
+	// a single peg in a corner of the center block can not be reached (color invariant)
+	if _, err := SolveParallel(context.Background(), INITIAL_BOARD, 1<<coordToBit(2, 2), 2, 1); err != ErrNoSolution {
+		t.Errorf("got %v, want ErrNoSolution", err)
+	}
+}
+
+// all workers have stopped when SolveParallel returns, whether a solution was found (which
+// cancels the other workers), the search was canceled or there is no solution
+func TestSolveParallelNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	start := boardAfterMoves(t, 18)
+	if _, err := SolveParallel(context.Background(), start, GOAL_BOARD, 8, 0); err != nil {
+		t.Fatal(err)
+	}
+	// the workers usually need far longer than this for the full board, but a worker only
+	// checks its context every contextCheckInterval boards, so a lucky one may still solve
+	// its task after the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if path, err := SolveParallel(ctx, INITIAL_BOARD, GOAL_BOARD, 8, 0); err == nil {
+		if err := VerifySolution(INITIAL_BOARD, GOAL_BOARD, path); err != nil {
+			t.Error(err)
+		}
+	} else if err != context.DeadlineExceeded {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+	if _, err := SolveParallel(context.Background(), start, 1<<coordToBit(2, 2), 8, 0); err != ErrNoSolution {
+		t.Errorf("got %v, want ErrNoSolution", err)
+	}
+	// goroutines of the test framework may take a moment to go away as well
+	for i := 0; runtime.NumGoroutine() > before; i++ {
+		if i == 100 {
+			t.Fatalf("%d goroutines left running, %d before", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,196 @@
+package solitaire
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+)
+
+// every hint used in a challenge adds this much to the reported time
+const hintPenalty = 30 * time.Second
+
+// the longest time spent searching for a hint
+const hintTimeout = 10 * time.Second
+
+// the outcome of an interactive game
+type gameResult struct {
+	won     bool
+	moves   int
+	hints   int
+	elapsed time.Duration
+}
+
+// create a random puzzle that can be solved in the given number of moves, by doing random
+// moves in reverse starting from the goal board - if no more reverse move is possible
+// before, the puzzle needs fewer moves
+func RandomPuzzle(moves int) uint64 {
+	board := GOAL_BOARD
+	for i := 0; i < moves; i++ {
+		var candidates []Move
+		for _, move := range defaultBoard.Moves {
+			if IsReverseLegal(move, board) && (Holes(board)&move.before) == move.before {
+				candidates = append(candidates, move)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		board ^= candidates[rand.Intn(len(candidates))].all
+	}
+	return board
+}
+
+// play a random puzzle of the given difficulty (number of moves) reading the moves from
+// the input, and report the time needed - a solved challenge is added to the leaderboard
+// file (unless it is empty)
+func PlayChallenge(difficulty int, in io.Reader, leaderboard string) {
+	start := RandomPuzzle(difficulty)
+	fmt.Printf("challenge: solve the puzzle in %d moves, every hint adds %v to your time\n",
+		PegCount(start)-PegCount(GOAL_BOARD), hintPenalty)
+	result := playGame(start, GOAL_BOARD, in)
+	if !result.won {
+		return
+	}
+	total := result.elapsed + time.Duration(result.hints)*hintPenalty
+	fmt.Printf("solved in %v with %d moves and %d hints (total time %v)\n",
+		result.elapsed.Round(time.Millisecond), result.moves, result.hints, total.Round(time.Millisecond))
+	if leaderboard == "" {
+		return
+	}
+	record := ChallengeRecord{
+		Date:   time.Now(),
+		Puzzle: fmt.Sprintf("%#x", start),
+		Moves:  result.moves,
+		Hints:  result.hints,
+		Time:   result.elapsed,
+		Total:  total,
+	}
+	if err := addToLeaderboard(leaderboard, record); err != nil {
+		fmt.Fprintln(os.Stderr, "the result could not be saved:", err)
+	}
+}
+
+// play the start board interactively without a clock, reading the moves from the input
+func PlayBoard(start uint64, in io.Reader) {
+	fmt.Println("enter moves in from-to notation (e.g. d2-d4), \"hint\", \"undo\", \"redo\" or \"quit\"")
+	result := playGame(start, GOAL_BOARD, in)
+	if result.won {
+		fmt.Printf("solved with %d moves and %d hints\n", result.moves, result.hints)
+	}
+}
+
+// get a move leading towards the goal from the board: from the known solution if it fits
+// the board, otherwise by a search from the board for at most hintTimeout. Returns
+// ErrNoSolution if the goal can not be reached from the board
+func hintMove(board uint64, goal uint64) (Move, error) {
+	if path, ok := KnownSolution(board, goal); ok && len(path) > 1 {
+		return moveBetween(path[0], path[1]), nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hintTimeout)
+	defer cancel()
+	moves, err := PreviewMoves(ctx, board, goal, 1)
+	if err != nil {
+		return Move{}, err
+	}
+	if len(moves) == 0 {
+		return Move{}, ErrNoSolution
+	}
+	return moves[0], nil
+}
+
+// play a game interactively: every line of the input is a move in from-to notation or
+// one of the commands "hint", "undo", "redo" and "quit"
+func playGame(start uint64, goal uint64, in io.Reader) gameResult {
+	var result gameResult
+	startTime := time.Now()
+	// all boards played so far, for undo, and the boards taken back, for redo (the last one
+	// taken back first)
+	history := []uint64{start}
+	var undone []uint64
+	board := start
+	printBoard(board, board)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		if board == goal {
+			result.won = true
+			break
+		}
+		if Mobility(board) == 0 {
+			fmt.Println("no more moves possible - type \"undo\" to take back a move or \"quit\"")
+		}
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			break
+		}
+		input := strings.TrimSpace(scanner.Text())
+		switch input {
+		case "":
+			continue
+		case "quit":
+			result.elapsed = time.Since(startTime)
+			return result
+		case "undo":
+			if len(history) == 1 {
+				fmt.Println("nothing to undo")
+				continue
+			}
+			undone = append(undone, board)
+			result.moves--
+			history = history[:len(history)-1]
+			board = history[len(history)-1]
+			printBoard(board, board)
+			continue
+		case "redo":
+			if len(undone) == 0 {
+				fmt.Println("nothing to redo")
+				continue
+			}
+			next := undone[len(undone)-1]
+			undone = undone[:len(undone)-1]
+			result.moves++
+			history = append(history, next)
+			printBoard(next, board)
+			board = next
+			continue
+		case "hint":
+			result.hints++
+			move, err := hintMove(board, goal)
+			if errors.Is(err, ErrNoSolution) {
+				fmt.Println("the goal can not be reached from this board anymore")
+				continue
+			} else if err != nil {
+				fmt.Printf("no hint found within %v\n", hintTimeout)
+				continue
+			}
+			// show the board after the move with the moved pegs highlighted
+			next, _ := Apply(board, move)
+			fmt.Printf("try %s (%s), it leads to:\n", MoveString(move), MoveDescription(move))
+			printBoard(next, board)
+			continue
+		}
+		move, err := ParseMove(input)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		next, ok := Apply(board, move)
+		if !ok {
+			fmt.Printf("%s is not a legal move\n", input)
+			continue
+		}
+		result.moves++
+		history = append(history, next)
+		undone = nil
+		printBoard(next, board)
+		board = next
+	}
+	result.elapsed = time.Since(startTime)
+	return result
+}
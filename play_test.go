@@ -0,0 +1,50 @@
+package solitaire
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// undo takes the move back from the count of moves, redo makes it again
+func TestPlayGameUndoRedo(t *testing.T) {
+	input := "d2-d4\nf3-d3\nundo\nundo\nredo\nquit\n"
+	if result := playGame(INITIAL_BOARD, GOAL_BOARD, strings.NewReader(input)); result.moves != 1 {
+		t.Errorf("got %d moves, want 1", result.moves)
+	}
+	input = strings.Join(englishSolution[:3], "\n") + "\nundo\n" + strings.Join(englishSolution[2:], "\n") + "\n"
+	result := playGame(INITIAL_BOARD, GOAL_BOARD, strings.NewReader(input))
+	if !result.won || result.moves != len(englishSolution) {
+		t.Errorf("got %d moves (won %v), want %d", result.moves, result.won, len(englishSolution))
+	}
+}
+
+// a hint leads to the goal of the game, also if it is not GOAL_BOARD
+func TestHintMoveGoal(t *testing.T) {
+	start := boardAfterMoves(t, 27)
+	reachable := 0
+	for cell := 0; cell < 49; cell++ {
+		goal := uint64(1) << cell
+		if goal&defaultBoard.ValidCells == 0 || goal == GOAL_BOARD {
+			continue
+		}
+		move, err := hintMove(start, goal)
+		if CountSolutionsMemoized(start, goal) == 0 {
+			if !errors.Is(err, ErrNoSolution) {
+				t.Errorf("goal %s: got %v, want ErrNoSolution", cellName(cell), err)
+			}
+			continue
+		}
+		reachable++
+		if err != nil {
+			t.Fatalf("goal %s: %v", cellName(cell), err)
+		}
+		next, ok := Apply(start, move)
+		if !ok || CountSolutionsMemoized(next, goal) == 0 {
+			t.Errorf("goal %s: the hint %s does not lead to the goal", cellName(cell), MoveString(move))
+		}
+	}
+	if reachable == 0 {
+		t.Error("no other goal is reachable from the start")
+	}
+}
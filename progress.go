@@ -0,0 +1,44 @@
+package solitaire
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// a progress report of a running search as written to Solver.Progress
+type progressEvent struct {
+	// number of boards visited so far
+	Nodes uint64 `json:"nodes"`
+	// moves between the goal and the board currently visited (see Depth)
+	Depth int `json:"depth"`
+	// number of boards in the seen set
+	Seen int `json:"seen"`
+	// milliseconds since the start of the search
+	Millis int64 `json:"t"`
+	// set for the last report written once the search is done
+	Done bool `json:"done,omitempty"`
+}
+
+// write the progress of the running search to Progress if the last report is at least
+// ProgressInterval old, the final report (once the search is done) is always written
+// Note: this is called from the search itself, so it can read the seen boards
+func (s *Solver) reportProgress(final bool) {
+	interval := s.ProgressInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	now := time.Now()
+	if !final && now.Sub(s.lastProgress) < interval {
+		return
+	}
+	s.lastProgress = now
+	event := progressEvent{
+		Nodes:  s.nodes.Load(),
+		Depth:  s.Depth(),
+		Seen:   len(s.seenBoards),
+		Millis: now.Sub(s.started).Milliseconds(),
+		Done:   final,
+	}
+	// the progress is only informational, so write errors do not stop the search
+	json.NewEncoder(s.Progress).Encode(event)
+}
@@ -0,0 +1,49 @@
+package solitaire
+
+import "math/bits"
+
+// constraints for Solver.Prune
+// Note: a constraint can make a board unsolvable that has a solution without it, the
+// search then fails with ErrNoSolution
+
+// build the masks of the adjacent cells of every cell (see Board.adjacentCells)
+func indexAdjacentCells(moves []Move) [49]uint64 {
+	var adjacent [49]uint64
+	for _, move := range moves {
+		from, over, to := moveCells(move)
+		adjacent[from] |= 1 << over
+		adjacent[over] |= 1<<from | 1<<to
+		adjacent[to] |= 1 << over
+	}
+	return adjacent
+}
+
+// check if a board has an isolated peg, i.e. a peg without any peg in the adjacent cells
+// (such a peg can only be removed once another peg moved next to it)
+func HasIsolatedPeg(board uint64) bool {
+	return defaultBoard.HasIsolatedPeg(board)
+}
+
+// check if a board has an isolated peg with the adjacent cells of the board, see
+// HasIsolatedPeg
+func (b *Board) HasIsolatedPeg(board uint64) bool {
+	for pegs := board; pegs != 0; pegs &= pegs - 1 {
+		if b.adjacentCells[bits.TrailingZeros64(pegs)]&board == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// get a constraint rejecting the boards with more than minPegs pegs that have an isolated
+// peg, so a solution only strands a peg with its last few moves
+func NoIsolatedPegs(minPegs int) func(board uint64) bool {
+	return defaultBoard.NoIsolatedPegs(minPegs)
+}
+
+// get the constraint of NoIsolatedPegs for the board
+func (b *Board) NoIsolatedPegs(minPegs int) func(board uint64) bool {
+	return func(board uint64) bool {
+		return PegCount(board) > minPegs && b.HasIsolatedPeg(board)
+	}
+}
@@ -0,0 +1,155 @@
+package solitaire
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// scoring variants award points for the moves of a solution, the score of a solution is
+// the sum of the points of its moves (the cells of the last pegs are given by the goal)
+type Scoring struct {
+	// the points of a move, step is the number of moves made before it
+	Points func(move Move, step int) int
+
+	// optional upper bound of the points the remaining moves from a board (reached after
+	// step moves) can make - it is used to cut off the search for the best score, so it
+	// must never be lower than the real value
+	Bound func(board uint64, step int) int
+}
+
+// score a move by the value of the cell of the peg it removes
+func CellValueScoring(values [49]int) *Scoring {
+	return defaultBoard.CellValueScoring(values)
+}
+
+// score a move by the value of the cell of the peg it removes, bounded by the values of the
+// valid cells of the board
+func (b *Board) CellValueScoring(values [49]int) *Scoring {
+	return &Scoring{
+		Points: func(move Move, step int) int {
+			_, over, _ := moveCells(move)
+			return values[over]
+		},
+		// every move removes one peg, but a cell can be refilled and jumped over again, so
+		// each of the remaining moves may score the highest value
+		Bound: func(board uint64, step int) int {
+			highest := 0
+			for cell := 0; cell < 49; cell++ {
+				if b.ValidCells&(1<<cell) != 0 {
+					highest = max(highest, values[cell])
+				}
+			}
+			return highest * (PegCount(board) - 1)
+		},
+	}
+}
+
+// parse a list of cell values like "d4=5,c1=-2" into the value of every cell, the cells
+// that are not listed get the default value
+func parseCellValues(text string, defaultValue int) ([49]int, error) {
+	var values [49]int
+	for cell := range values {
+		values[cell] = defaultValue
+	}
+	for _, entry := range strings.Split(text, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found {
+			return values, fmt.Errorf("invalid cell value %q: expected cell=value", entry)
+		}
+		cell, err := parseCell(name)
+		if err != nil {
+			return values, err
+		}
+		if values[cell], err = strconv.Atoi(value); err != nil {
+			return values, fmt.Errorf("invalid cell value %q: %v", entry, err)
+		}
+	}
+	return values, nil
+}
+
+// get the score of a solution
+func (scoring *Scoring) Score(path []uint64) int {
+	score := 0
+	for step, move := range SolutionMoves(path) {
+		score += scoring.Points(move, step)
+	}
+	return score
+}
+
+// find the solution with the highest score by a forward depth first search over all
+// solutions, cutting off every board whose score can not beat the best one found so far
+// (see Scoring.Bound). Boards from which the goal can not be reached are remembered
+// Note: without a tight bound this has to look at every solution, so it is only feasible
+// for boards with few pegs
+func (s *Solver) searchMaxScore(start uint64) {
+	w := scoreSearch{
+		s:        s,
+		goal:     s.goal.Load(),
+		goalPegs: PegCount(s.goal.Load()),
+		dead:     map[uint64]bool{},
+		best:     math.MinInt,
+		path:     []uint64{start},
+	}
+	w.walk(start, 0)
+}
+
+// state of searchMaxScore
+type scoreSearch struct {
+	s        *Solver
+	goal     uint64
+	goalPegs int
+	// the boards from which the goal can not be reached
+	dead map[uint64]bool
+	// the score of the current path and of the best solution found so far
+	score int
+	best  int
+	path  []uint64
+}
+
+// continue the current path (ending in the board) towards the goal, returns false if the
+// goal can not be reached from the board
+func (w *scoreSearch) walk(board uint64, step int) bool {
+	s := w.s
+	s.visit(board)
+	if s.nodes.Add(1)%contextCheckInterval == 0 && s.ctx.Err() != nil {
+		s.err = s.ctx.Err()
+	}
+	if s.err != nil {
+		return true
+	}
+	if board == w.goal {
+		if w.score > w.best {
+			w.best = w.score
+			s.Solution = append([]uint64(nil), w.path...)
+		}
+		return true
+	}
+	if PegCount(board) <= w.goalPegs || w.dead[board] {
+		return false
+	}
+	// the board may still lead to the goal, it is just not worth searching
+	if bound := s.Scoring.Bound; bound != nil && w.best != math.MinInt && w.score+bound(board, step) <= w.best {
+		return true
+	}
+	reachable := false
+	for _, move := range s.moves {
+		next, ok := Apply(board, move)
+		if !ok || !s.allows(board, move) || (next != w.goal && s.Prune != nil && s.Prune(next)) {
+			continue
+		}
+		points := s.Scoring.Points(move, step)
+		w.score += points
+		w.path = append(w.path, next)
+		if w.walk(next, step+1) {
+			reachable = true
+		}
+		w.path = w.path[:len(w.path)-1]
+		w.score -= points
+	}
+	if !reachable {
+		w.dead[board] = true
+	}
+	return reachable
+}
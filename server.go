@@ -0,0 +1,103 @@
+package solitaire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// the HTTP server offers one endpoint: POST /solve with a JSON body like
+//
+//	{"board": ["  XXX", "  XXX", "XXXXXXX", "XXX0XXX", "XXXXXXX", "  XXX", "  XXX"]}
+//
+// where "board" holds the 7 lines of the start board in the text format (see ParseBoard)
+// and an optional "goal" in the same format (defaults to GOAL_BOARD). The response is
+//
+//	{"solved": true, "moves": ["d2-d4", ...], "boards": [[...], ...]}
+//
+// with the moves in from-to notation and all boards of the solution in the text format
+
+// the body of a solve request
+type solveRequest struct {
+	Board []string `json:"board"`
+	Goal  []string `json:"goal,omitempty"`
+}
+
+// the body of a solve response
+type solveResponse struct {
+	Solved bool       `json:"solved"`
+	Moves  []string   `json:"moves,omitempty"`
+	Boards [][]string `json:"boards,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// maximum size of a request body
+const maxRequestSize = 1 << 16
+
+// start an HTTP server on the given address, each board is searched for at most "timeout"
+// if a transposition table is given, it is shared by the solvers of all requests
+func Serve(addr string, timeout time.Duration, table *TranspositionTable) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/solve", func(w http.ResponseWriter, r *http.Request) {
+		handleSolve(w, r, timeout, table)
+	})
+	return http.ListenAndServe(addr, mux)
+}
+
+// handle a solve request - every request gets its own solver
+func handleSolve(w http.ResponseWriter, r *http.Request, timeout time.Duration, table *TranspositionTable) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeResponse(w, http.StatusMethodNotAllowed, solveResponse{Error: "only POST is supported"})
+		return
+	}
+
+	var request solveRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, solveResponse{Error: "invalid request: " + err.Error()})
+		return
+	}
+	start, err := parseBoardLines(request.Board)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, solveResponse{Error: "invalid board: " + err.Error()})
+		return
+	}
+	goal := GOAL_BOARD
+	if request.Goal != nil {
+		if goal, err = parseBoardLines(request.Goal); err != nil {
+			writeResponse(w, http.StatusBadRequest, solveResponse{Error: "invalid goal: " + err.Error()})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	solver := NewSolver()
+	solver.SetOrder("random")
+	solver.Table = table
+	_, err = solver.Solve(ctx, start, goal)
+	switch {
+	case errors.Is(err, ErrNoSolution):
+		writeResponse(w, http.StatusOK, solveResponse{Solved: false})
+	case err != nil:
+		writeResponse(w, http.StatusServiceUnavailable, solveResponse{Error: "search aborted: " + err.Error()})
+	default:
+		response := solveResponse{Solved: true}
+		for _, move := range SolutionMoves(solver.Solution) {
+			response.Moves = append(response.Moves, MoveString(move))
+		}
+		for _, board := range solver.Solution {
+			response.Boards = append(response.Boards, boardLines(board))
+		}
+		writeResponse(w, http.StatusOK, response)
+	}
+}
+
+// write a JSON response
+func writeResponse(w http.ResponseWriter, status int, response solveResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
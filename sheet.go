@@ -0,0 +1,43 @@
+package solitaire
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// write a solution as a plain text sheet for printing: every board is numbered and shown
+// with the move leading to it beside it, with a fixed number of boards per page. Every page
+// starts with a header, pages are separated by form feeds
+func WriteSheet(w io.Writer, path []uint64, perPage int) error {
+	if perPage < 1 {
+		perPage = 1
+	}
+	pages := (len(path) + perPage - 1) / perPage
+	var sheet strings.Builder
+	for page := 0; page < pages; page++ {
+		if page > 0 {
+			sheet.WriteString("\f")
+		}
+		fmt.Fprintf(&sheet, "peg solitaire solution, %d moves - page %d of %d\n\n", len(path)-1, page+1, pages)
+		for step := page * perPage; step < min((page+1)*perPage, len(path)); step++ {
+			caption := "start"
+			if step > 0 {
+				caption = MoveString(moveBetween(path[step-1], path[step]))
+			}
+			for row, line := range boardLines(path[step]) {
+				label := ""
+				if row == 0 {
+					label = fmt.Sprintf("%d.", step)
+				}
+				if row == 3 {
+					line = fmt.Sprintf("%-7s   %s", line, caption)
+				}
+				fmt.Fprintf(&sheet, "%4s  %s\n", label, line)
+			}
+			sheet.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, sheet.String())
+	return err
+}
@@ -1,19 +1,33 @@
-package main
+// Package solitaire solves peg solitaire puzzles on the English board and other variants,
+// see Solver; the command line program (cmd/solitaire) runs Main
+package solitaire
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"log"
+	"math/bits"
 	"math/rand"
+	"os"
+	"os/signal"
+	"slices"
 	"strconv"
+	"sync/atomic"
+	"time"
 )
 
 // the below constants are binary representations of the bitmaps that model the board
 // a "1" represents a marble in the slot, a "0" rpresents an empty slot
-// However, in VALID_BOARD_CELLS a "1" represents a valid slot
+// However, in englishCells a "1" represents a valid slot
 
 // the route via strconv is done to break the binary numbers into multiple lines to visualise the board
 
-// Valid Cells that can contain a ball (i.e. thev available slots)
-var VALID_BOARD_CELLS, _ = strconv.ParseUint("0"+
+// Valid Cells that can contain a ball (i.e. thev available slots) of the English board, see
+// Board.ValidCells for the cells of the selected variant
+var englishCells, _ = strconv.ParseUint("0"+
 	"0011100"+
 	"0011100"+
 	"1111111"+
@@ -36,8 +50,8 @@ var INITIAL_BOARD, _ = strconv.ParseUint("0"+
 var GOAL_BOARD, _ = strconv.ParseUint("0"+
 	"0000000"+
 	"0000000"+
-	"0001000"+
 	"0000000"+
+	"0001000"+
 	"0000000"+
 	"0000000"+
 	"0000000", 2, 64)
@@ -50,74 +64,1411 @@ type Move struct {
 	after, before, all uint64
 }
 
-// Global Variables:
+// returned if there is no solution for a board
+var ErrNoSolution = errors.New("no solution exists")
+
+// stops a search that has used up its budget of visited boards (see SymmetryFallback)
+var errNodeBudget = errors.New("node budget exceeded")
+
+// returned if a board has a peg outside of the valid cells
+var ErrInvalidCell = errors.New("peg outside of the board")
+
+// a Solver holds the state of one search, so several searches can run at the same time
+type Solver struct {
+	// the board searched, its valid cells and moves (see NewBoardSolver) - every solver has
+	// its own, so solvers of different variants can run side by side
+	Board *Board
+	// holds all 76 moves that are possible in the order they are tried by the search
+	Moves []Move
+	// optional source of randomness for SetOrder("random"), e.g. rand.New(rand.NewSource(seed))
+	// for a reproducible order - the shared source of math/rand is used if nil
+	Rand *rand.Rand
+
+	// list of solution boards in ascending order - filled in once the solution is found
+	Solution []uint64
+
+	// what the search looks for, all objectives other than FirstSolution ignore Score, Table,
+	// Progress and the statistics (but not FixedCells and Prune)
+	Objective SearchObjective
+
+	// optional scoring of the moves: at every board the applicable moves are tried in the
+	// order of descending score (see scoredMoves). The board passed is the one the move is
+	// undone on, i.e. the board after the move in playing order, since the search runs in reverse
+	Score func(move Move, board uint64) int
+
+	// optional points of the moves for scoring variants, required by the MaxScore objective
+	// (the score of the solution found is part of the result of Solve)
+	Scoring *Scoring
+
+	// cells holding pegs that may neither be moved nor jumped over, they have to be occupied in
+	// the start board and stay in place until the goal board
+	FixedCells uint64
+
+	// optional constraints on the moves of a solution, all of them have to allow a move for
+	// it to be tried (FixedCells and ValidCells are added to them by the search)
+	Constraints []Constraint
+
+	// optional constraint on the boards of a solution: boards (other than the start and the
+	// goal) for which it returns true are not explored, see NoIsolatedPegs
+	Prune func(board uint64) bool
+
+	// optional region of the board the search is restricted to (0 for the whole board): only
+	// the moves within the region are used and pegs outside of it are treated like pegs
+	// outside of the board
+	ValidCells uint64
+
+	// pegs outside of the valid cells make Solve fail with ErrInvalidCell, if this is set
+	// they are removed from the start and goal board instead
+	MaskInvalidCells bool
+
+	// if set, the search writes its progress to this writer as one JSON object per line
+	// (see progressEvent), at most once per ProgressInterval (default one second)
+	Progress         io.Writer
+	ProgressInterval time.Duration
+
+	// enables symmetry pruning (set by NewSolver): boards that are a rotation or reflection
+	// of a seen board are not searched again. Only the transforms mapping the start and the
+	// goal board (and FixedCells and ValidCells) onto themselves are used, e.g. all of them
+	// for the standard puzzle, and none if there are Constraints or Prune. Every visited
+	// board costs more time, but on the standard puzzle this visits about 40% fewer boards
+	// and is slightly faster overall
+	Symmetry bool
+
+	// if set (and Symmetry is not), the search starts without symmetry pruning, which is
+	// faster for easy boards, and restarts with it after visiting this many boards - the
+	// boards visited before the restart are lost and counted in the statistics, so a hard
+	// board costs this many boards more than with Symmetry. The solution consists of the
+	// actual boards of the path in either case
+	SymmetryFallback uint64
+
+	// if set, the reverse search keeps the boards of the current path on an explicit stack
+	// instead of recursing (see searchIterative). It visits the same boards in the same order
+	// and finds the same solution
+	Iterative bool
+
+	// optional transposition table consulted before and filled after every search, it can
+	// be shared with other solvers (opt-in since its entries are only valid for one variant)
+	Table *TranspositionTable
+
+	// boards that can not be reached from the start board, shared with the other workers of
+	// SolveParallel: the search skips them and adds the boards it failed on
+	dead *deadBoards
+
+	// enables the collection of search statistics (see SearchStats)
+	CollectStats bool
+
+	// if the seen boards of a search grow past this number (0 for no limit), the search sets
+	// Stats.SeenBoardsExceeded and writes a warning to Warnings (if set) once, since this
+	// hints at a state explosion that may use up all memory - the search goes on regardless
+	SeenBoardsWarning int
+	Warnings          io.Writer
+
+	// statistics of the last search
+	Stats SearchStats
+
+	// enables recording of the deepest partial path explored by the search, which
+	// helps to understand why a board can not be solved
+	FailureDiagnostics bool
+
+	// the deepest partial path explored by the last search - since the search runs
+	// in reverse, this is a sequence of legal moves that ends in the goal board
+	// but starts at the board furthest away from it that the search could reach
+	DeepestPath []uint64
+
+	// the symmetries used by the running search for symmetry pruning (see seenKey) and the
+	// number of visited boards after which it restarts with symmetry pruning (0 for never)
+	symmetries []Transform
+	budget     uint64
+
+	// the moves of the last search, i.e. Moves without those rejected by a constraint that does
+	// not depend on the board (e.g. the moves involving a fixed cell), and the other constraints
+	moves            []Move
+	boardConstraints []Constraint
+
+	// the path from the goal board to the board currently visited by the search
+	currentPath []uint64
+
+	// list of seen boards - this is used to prevent rechecking of paths
+	seenBoards map[uint64]bool
+
+	// the board the (reverse) search is looking for, i.e. the start board of the game
+	target uint64
+
+	// the peg count of the target board - every reverse move adds one peg, so boards
+	// with this many pegs (other than the target itself) are dead ends
+	targetPegs int
+
+	// the context of the running search and the error that stopped it (if any)
+	ctx context.Context
+	err error
+
+	// progress of the running search, these can be read while the search is running
+	// (see NodesVisited, Depth and EstimatedMemory) and are therefore updated atomically
+	nodes   atomic.Uint64
+	current atomic.Uint64
+	goal    atomic.Uint64
+	// the largest number of moves a visited board was away from the board the search began
+	// with (the goal for the reverse search, the start for the others), see MaxDepth
+	deepest    atomic.Int64
+	originPegs int
+	// the number of seen boards, updated every contextCheckInterval visited boards
+	seen atomic.Uint64
+
+	// when the running search was started and its progress was reported the last time
+	started      time.Time
+	lastProgress time.Time
+}
+
+// create a solver for the board of the selected variant (see UseVariant) trying the moves
+// in the order they are generated
+func NewSolver() *Solver {
+	return NewBoardSolver(defaultBoard)
+}
+
+// create a solver for the given board, e.g. Variants["european"].Board(), trying the moves
+// in the order they are generated
+func NewBoardSolver(board *Board) *Solver {
+	return &Solver{
+		Board:    board,
+		Moves:    append(make([]Move, 0, len(board.Moves)), board.Moves...),
+		Symmetry: true,
+	}
+}
+
+// forget the results of the last search (the seen boards, the solution and the statistics)
+// but keep the moves and all settings, so the solver can search again right away - Solve
+// does this itself, so this is only needed to release the memory of the seen boards early
+func (s *Solver) Reset() {
+	s.seenBoards = map[uint64]bool{}
+	s.Stats = SearchStats{BranchingFactor: map[int]int{}}
+	s.DeepestPath = nil
+	s.currentPath = s.currentPath[:0]
+	s.Solution = nil
+	s.err = nil
+}
+
+// find a solution leading from the initial to the goal board with a new solver trying
+// the moves in random order, returns the boards of the solution (starting with the initial
+// board) or ErrNoSolution if there is none
+func Solve(initial uint64, goal uint64) ([]uint64, error) {
+	solver := NewSolver()
+	solver.SetOrder("random")
+	if _, err := solver.Solve(context.Background(), initial, goal); err != nil {
+		return nil, err
+	}
+	return solver.Solution, nil
+}
+
+// solve the standard puzzle (start and goal of the selected variant) the same way every
+// time: the moves are tried in the order they are generated, so among the applicable moves
+// the one with the lowest index always comes first and the solution never changes
+func SolveCanonical() ([]uint64, error) {
+	solver := NewSolver()
+	if _, err := solver.Solve(context.Background(), INITIAL_BOARD, GOAL_BOARD); err != nil {
+		return nil, err
+	}
+	return solver.Solution, nil
+}
+
+// the flags of the command line program, in a flag set of their own so the package does not
+// register them for the programs importing it
+var commandLine = flag.NewFlagSet("solitaire", flag.ExitOnError)
+
+// command line flags
+var printFinal = commandLine.Bool("final", false, "print only the final board of the solution")
+var describe = commandLine.Bool("describe", false, "print the solution in words instead of drawing the boards")
+var printAll = commandLine.Bool("all", false, "print all solutions as they are found")
+var maxSolutions = commandLine.Int("limit", 0, "maximum number of solutions printed by -all (0 for no limit)")
+var uniqueSolutions = commandLine.Bool("unique", false, "with -all, print only one solution of each group of symmetric solutions")
+var printCSV = commandLine.Bool("csv", false, "print the moves of the solution as CSV (bit indices of the involved cells)")
+var reverseOrder = commandLine.Bool("reverse-order", false, "print the boards of the solution from the goal back to the start")
+var export = commandLine.Bool("export", false, "print the solution in a single line with a checksum which can be replayed with -replay")
+var verify = commandLine.String("verify", "", "check the moves of the given solution file (in the format of -moves) and print PASS or FAIL instead of solving")
+var startFile = commandLine.String("start", "", "start board to solve (and for -verify): a file in the format of -boards, or the board inline like -grid or as its 33 cells")
+var goalBoard = commandLine.String("goal", "", "goal board instead of the one of the variant: a file in the format of -boards, or the board inline like -grid or as its 33 cells")
+var replay = commandLine.String("replay", "", "print the solution given in the format of -export instead of solving")
+var printMoves = commandLine.Bool("moves", false, "print only the moves of the solution in from-to notation")
+var printStats = commandLine.Bool("stats", false, "collect and print search statistics (slows down the search)")
+var diagnose = commandLine.Bool("diagnose", false, "print the deepest partial path if no solution is found")
+var printProgress = commandLine.Bool("progress", false, "write the progress of the search to stderr as one JSON object per line")
+var countSolutions = commandLine.Bool("count", false, "count the solutions of the start board instead of solving (only feasible for boards with few pegs)")
+var minUndos = commandLine.Bool("undos", false, "print the fewest undos of losing moves with which a win is guaranteed whatever moves are made (only feasible for boards with few pegs)")
+var openings = commandLine.Bool("openings", false, "count the solutions of every opening move instead of solving, the hardest first (only feasible for boards with few pegs)")
+var progressEvery = commandLine.Uint64("progress-every", 1000000, "with -count and -progress, report the progress after every that many solutions")
+var printTime = commandLine.Bool("time", false, "print the time needed to find the solution to stderr")
+var seed = commandLine.Int64("seed", 0, "seed of the random move order, so the same solution is found again (0 for a random seed)")
+var moveOrder = commandLine.String("order", "random", "order in which the moves are tried: random, center or generated")
+var boardsFile = commandLine.String("boards", "", "solve all start boards of the given file instead of the standard board")
+var grid = commandLine.String("grid", "", "solve the start board given inline, its 7 lines separated by ';' or '/'")
+var edit = commandLine.Bool("edit", false, "build the start board interactively before solving it")
+var dumpModel = commandLine.Bool("dump-model", false, "print the valid cells and all moves in a text format for external tools")
+var graphFile = commandLine.String("graph", "", "write the graph of all boards reachable from the (first) start board to a DOT file")
+var graphLimit = commandLine.Int("graph-limit", 1000, "maximum number of boards written by -graph")
+var countReachable = commandLine.Bool("reachable", false, "count the boards reachable from the start board instead of solving")
+var reachableLimit = commandLine.Int("reachable-limit", 1000000, "maximum number of boards counted by -reachable (0 for no limit)")
+var randomPuzzles = commandLine.Int("random", 0, "solve that many random puzzles and print how many were solved")
+var removePegs = commandLine.Int("remove", 0, "with -random, use random boards with that many pegs removed from the full board")
+var challenge = commandLine.Bool("challenge", false, "play a random puzzle interactively against the clock")
+var play = commandLine.Bool("play", false, "play the start board interactively with hints, undo and redo instead of solving")
+var leaderboard = commandLine.Bool("leaderboard", false, "print the challenges completed so far, the fastest first")
+var leaderboardPath = commandLine.String("leaderboard-file", "", "file the completed challenges are saved in (default ~/.solitaire-leaderboard.json, \"none\" to not save them)")
+var difficulty = commandLine.Int("difficulty", 10, "number of moves needed to solve the puzzles of -challenge and -random")
+var jsonState = commandLine.Bool("json-state", false, "print the complete result of the solve as one JSON object")
+var singleHole = commandLine.Bool("single-holes", false, "check which starts with a single empty cell can be solved")
+var uniqueDepth = commandLine.Int("unique-boards", 0, "list the boards that many moves away from the goal with exactly one solution up to symmetry")
+var maxCandidates = commandLine.Int("max-candidates", 10000, "maximum number of boards checked by -unique-boards (0 for no limit)")
+var serveAddr = commandLine.String("serve", "", "start an HTTP server solving boards on the given address (e.g. :8080)")
+var torusMoves = commandLine.Bool("torus", false, "let the jumps wrap around the edges of the board (on a torus)")
+var variantName = commandLine.String("variant", "english", "board variant to play: "+variantNames())
+var boardName = commandLine.String("board", "", "board geometry to play, like -variant (which it takes precedence over)")
+var sharedTable = commandLine.Bool("table", false, "let the server share a transposition table between all requests")
+var outputFormat = commandLine.String("output", "text", "how the solution is printed: text (the boards), json (like -json-state) or moves (like -moves)")
+var noColor = commandLine.Bool("no-color", false, "print the boards without ANSI colors, for terminals without them and for post-processing")
+var styleName = commandLine.String("style", "ascii", "how the boards are drawn: ascii (X and 0), unicode (● and ○) or box (unicode with a border)")
+var perRow = commandLine.Int("per-row", 8, "number of boards printed side by side (0 to fit the terminal width)")
+var bidirectional = commandLine.Bool("bidirectional", false, "search from the start and the goal board at once (needs a lot of memory)")
+var maxBoards = commandLine.Int("max-boards", 10000000, "maximum number of boards kept by -bidirectional (0 for no limit)")
+var longestSweep = commandLine.Bool("sweep", false, "find the solution with the longest chain of jumps by one peg (slow)")
+var pegGolf = commandLine.Bool("golf", false, "find the solution in which the fewest distinct pegs move (slow)")
+var searchMode = commandLine.String("mode", "first", "what to search for: first (any solution), optimal (the solution with the fewest moves, multiple jumps counting as one) or count (the number of solutions)")
+var clustered = commandLine.Bool("clustered", false, "look for a solution keeping the pegs close together (heuristic, keeps all visited boards in memory)")
+var cellValues = commandLine.String("cell-values", "", "find the solution with the highest score, each removed peg scores the value of its cell (e.g. d4=5,c1=-2, default 1)")
+var printForced = commandLine.Bool("forced", false, "print how many moves of the solution were the only legal move")
+var sheet = commandLine.Bool("sheet", false, "print the solution as a plain text sheet for printing")
+var sheetSteps = commandLine.Int("sheet-steps", 6, "number of boards per page of -sheet")
+var strategy = commandLine.Bool("strategy", false, "solve the standard puzzle of the English board with the block strategy without searching, search other boards")
+var astar = commandLine.Bool("astar", false, "use a best first (A*) search with the peg count heuristic")
+var parallel = commandLine.Bool("parallel", false, "search with a pool of workers in parallel")
+var workers = commandLine.Int("workers", 0, "number of workers used by -parallel (0 for one per CPU)")
+var noIsolated = commandLine.Int("no-isolated", 0, "only allow isolated pegs (without adjacent pegs) on boards with at most that many pegs (0 to allow them always)")
+var fixedCells = commandLine.String("fixed", "", "comma separated cells (e.g. c1,e1) whose pegs may neither be moved nor jumped over")
+var pngFile = commandLine.String("png", "", "solve the start board shown in the given PNG image (dark pegs on a light 7 x 7 grid)")
+var region = commandLine.String("region", "", "comma separated cells (e.g. c3,d3,e3) the puzzle is restricted to, pegs outside of them are removed from the start board")
+var warnSeen = commandLine.Int("warn-seen", 50000000, "warn once the search has seen more than that many boards (0 to never warn)")
+var symmetry = commandLine.Bool("symmetry", true, "skip boards that are rotations or reflections of a seen board")
+var symmetryAfter = commandLine.Uint64("symmetry-after", 0, "with -symmetry=false, restart with -symmetry once the search has visited that many boards (0 to never)")
+var solveTimeout = commandLine.Duration("timeout", 10*time.Second, "maximum time spent on one board by -serve, -random and -critical")
+var markdown = commandLine.Bool("markdown", false, "print the solution as a Markdown document with a code block per board (in the -style)")
+var summary = commandLine.Bool("summary", false, "print only the start, the last and a few boards in between")
+var summaryFrames = commandLine.Int("frames", 5, "number of boards printed by -summary")
+var critical = commandLine.Bool("critical", false, "print only the critical moves of the solution, where most legal moves lose (slow)")
+var animate = commandLine.Bool("animate", false, "play the boards of the solution one by one in the terminal (space pauses, the arrow keys step, q quits)")
+var animateDelay = commandLine.Duration("delay", 700*time.Millisecond, "time each board is shown by -animate")
+
+// run the command line program with its arguments (without the program name), the flags are
+// described in the README. Errors are printed and exit the program with status 1
+func Main(args []string) {
+	commandLine.Parse(args)
+
+	name := *variantName
+	if *boardName != "" {
+		name = *boardName
+	}
+	variant, err := lookupVariant(name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *torusMoves {
+		variant = variant.OnTorus()
+	}
+	UseVariant(variant)
+	if *goalBoard != "" {
+		goal, err := readBoardArg(*goalBoard)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -goal:", err)
+			os.Exit(1)
+		}
+		GOAL_BOARD = goal
+	}
+	if err := SetBoardStyle(*styleName); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	colored = !*noColor
+	switch *outputFormat {
+	case "text":
+	case "json":
+		*jsonState = true
+	case "moves":
+		*printMoves = true
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output %q, available outputs: text, json, moves\n", *outputFormat)
+		os.Exit(1)
+	}
+
+	if *dumpModel {
+		if err := defaultBoard.DumpModel(os.Stdout, INITIAL_BOARD, GOAL_BOARD); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *randomPuzzles > 0 {
+		RunRandomPuzzles(*randomPuzzles, *removePegs, *difficulty, *solveTimeout)
+		return
+	}
+
+	if *replay != "" {
+		path, err := ImportSolution(*replay)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if *printMoves {
+			PrintMoves(path)
+		} else {
+			PrintSolution(path, boardsPerRow())
+		}
+		return
+	}
+
+	if *verify != "" {
+		start := INITIAL_BOARD
+		if *startFile != "" {
+			var err error
+			if start, err = readBoardArg(*startFile); err != nil {
+				fmt.Fprintln(os.Stderr, "invalid -start:", err)
+				os.Exit(1)
+			}
+		}
+		if !verifySolutionFile(*verify, start, GOAL_BOARD) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *serveAddr != "" {
+		var table *TranspositionTable
+		if *sharedTable {
+			table = NewTranspositionTable()
+		}
+		log.Fatal(Serve(*serveAddr, *solveTimeout, table))
+	}
+
+	if *singleHole {
+		EnumerateSingleHoleStarts()
+		return
+	}
+
+	if *uniqueDepth > 0 {
+		printUniqueBoards(*uniqueDepth)
+		return
+	}
+
+	if *leaderboard {
+		records, err := LoadLeaderboard(leaderboardFile())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		}
+		PrintLeaderboard(records)
+		return
+	}
+
+	if *challenge {
+		PlayChallenge(*difficulty, os.Stdin, leaderboardFile())
+		return
+	}
+
+	solver := NewSolver()
+	solver.CollectStats = *printStats
+	solver.FailureDiagnostics = *diagnose
+	solver.SeenBoardsWarning = *warnSeen
+	solver.Symmetry = *symmetry
+	solver.SymmetryFallback = *symmetryAfter
+	solver.Warnings = os.Stderr
+	if *printProgress {
+		solver.Progress = os.Stderr
+	}
+	if *longestSweep {
+		solver.Objective = LongestSweep
+	}
+	if *clustered {
+		solver.Objective = MostClustered
+	}
+	if *pegGolf {
+		solver.Objective = PegGolf
+	}
+	switch *searchMode {
+	case "first", "count":
+	case "optimal":
+		solver.Objective = FewestMoves
+	default:
+		fmt.Fprintf(os.Stderr, "unknown mode %q, available modes: first, optimal, count\n", *searchMode)
+		os.Exit(1)
+	}
+	if *cellValues != "" {
+		values, err := parseCellValues(*cellValues, 1)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		solver.Scoring = solver.Board.CellValueScoring(values)
+		// with -sweep the score is only reported
+		if !*longestSweep {
+			solver.Objective = MaxScore
+		}
+	}
+	if *noIsolated > 0 {
+		solver.Prune = solver.Board.NoIsolatedPegs(*noIsolated)
+	}
+	if *region != "" {
+		var err error
+		if solver.ValidCells, err = parseCells(*region); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		solver.MaskInvalidCells = true
+	}
+	if *fixedCells != "" {
+		var err error
+		if solver.FixedCells, err = parseCells(*fixedCells); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if err := checkConstraintFlags(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *seed != 0 {
+		solver.Rand = rand.New(rand.NewSource(*seed))
+	}
+	// order the moves (this highly influences the resulting runtime)
+	if err := solver.SetOrder(*moveOrder); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// solve all boards of the given file (or the default board)
+	boards := []uint64{INITIAL_BOARD}
+	if *boardsFile != "" {
+		var err error
+		if boards, err = readBoards(*boardsFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else if *edit {
+		board, ok := EditBoard(boards[0], os.Stdin)
+		if !ok {
+			return
+		}
+		boards = []uint64{board}
+	} else if *grid != "" {
+		board, err := ParseGrid(*grid)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -grid:", err)
+			os.Exit(1)
+		}
+		boards = []uint64{board}
+	} else if *pngFile != "" {
+		board, err := readPNG(*pngFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		boards = []uint64{board}
+	} else if *startFile != "" {
+		board, err := readBoardArg(*startFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -start:", err)
+			os.Exit(1)
+		}
+		boards = []uint64{board}
+	}
 
-// list of seen boards - this is used to prevent rechecking of paths
-var seenBoards = map[uint64]bool{}
+	if *graphFile != "" {
+		if err := writeGraph(*graphFile, boards[0], *graphLimit); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
-// list of solution boards in ascending order - filled in once the solution is found - array capcity 32 is based on known max. number of moves
-var Solution = make([]uint64, 0, 32)
+	if *play {
+		PlayBoard(boards[0], os.Stdin)
+		return
+	}
 
-// holds all 76 moves that are possible
-var Moves = make([]Move, 0, 76)
+	// the exit status tells scripts whether all boards could be solved
+	solved := true
+	for i, board := range boards {
+		if *boardsFile != "" {
+			fmt.Printf("board %d:\n", i+1)
+		}
+		if *countReachable {
+			printReachable(board, *reachableLimit)
+			continue
+		}
+		if *countSolutions {
+			printSolutionCount(board)
+			continue
+		}
+		if *searchMode == "count" {
+			fmt.Printf("%d solutions\n", CountSolutionsMemoized(board, GOAL_BOARD))
+			continue
+		}
+		if *openings {
+			PrintOpenings(board, GOAL_BOARD)
+			continue
+		}
+		if *minUndos {
+			printMinUndos(board)
+			continue
+		}
+		if *printAll {
+			if !runAll(board) {
+				solved = false
+			}
+			continue
+		}
+		// Ctrl-C stops the running search, which then prints what it has done so far (the
+		// other modes do not watch the context, so Ctrl-C ends them at once as usual)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		if !run(ctx, solver, board) {
+			solved = false
+		}
+		stop()
+	}
+	if !solved {
+		os.Exit(1)
+	}
+}
 
-func main() {
+// get the leaderboard file of -challenge and -leaderboard, "" if the results are not saved
+func leaderboardFile() string {
+	switch *leaderboardPath {
+	case "":
+		return defaultLeaderboardFile()
+	case "none":
+		return ""
+	}
+	return *leaderboardPath
+}
 
-	// generate all possible moves
+// print the boards with exactly one solution up to symmetry among the boards the given
+// number of moves away from the goal, in the format of -boards (the count goes to stderr)
+func printUniqueBoards(depth int) {
+	boards := UniqueSolutionBoards(BoardsAtReverseDepth(depth), GOAL_BOARD, *maxCandidates, *workers)
+	for i, board := range boards {
+		if i > 0 {
+			fmt.Println("---")
+		}
+		fmt.Println(FormatBoard(board))
+	}
+	fmt.Fprintf(os.Stderr, "%d boards with a unique solution\n", len(boards))
+}
 
-	// holds all starting positions in west-east direction
-	var startsX = [19]int{2, 9, 14, 15, 16, 17, 18, 21, 22, 23, 24, 25, 28, 29, 30, 31, 32, 37, 44}
-	for _, x := range startsX {
-		Moves = createMoves(x, x+1, x+2, Moves)
+// print the fewest undos with which a win is guaranteed from the start board (see MinUndos)
+func printMinUndos(start uint64) {
+	if undos, ok := MinUndos(start); ok {
+		fmt.Printf("a win is guaranteed with %d undo(s)\n", undos)
+	} else {
+		fmt.Println("no win is possible, the board can not be solved")
 	}
-	// holds all starting positions in north-south direction
-	var startsY = [19]int{2, 3, 4, 9, 10, 11, 14, 15, 16, 17, 18, 19, 20, 23, 24, 25, 30, 31, 32}
-	for _, y := range startsY {
-		Moves = createMoves(y, y+7, y+14, Moves)
+}
+
+// print the number of boards reachable from the start board, or a lower bound if there
+// are more than maxBoards of them
+func printReachable(start uint64, maxBoards int) {
+	boards, complete := ReachableBoards(start, maxBoards)
+	if complete {
+		fmt.Printf("%d reachable boards\n", len(boards))
+	} else {
+		fmt.Printf("at least %d reachable boards\n", len(boards))
 	}
+}
 
-	// randomize the order of the moves (this highly influences the resulting runtime)
-	rand.Shuffle(len(Moves), func(i, j int) { Moves[i], Moves[j] = Moves[j], Moves[i] })
+// print the number of solutions for the start board, with -progress also the number of
+// solutions found so far every -progress-every solutions (to stderr)
+func printSolutionCount(start uint64) {
+	var progress func(uint64, uint64)
+	if *printProgress {
+		progress = func(solutions uint64, nodes uint64) {
+			fmt.Fprintf(os.Stderr, "found %d solutions so far, nodes=%d\n", solutions, nodes)
+		}
+	}
+	fmt.Printf("%d solutions\n", CountSolutions(start, GOAL_BOARD, *progressEvery, progress))
+}
 
-	// add starting board (as this board is not added by the recursive function)
-	Solution = append(Solution, INITIAL_BOARD)
+// solve the given start board and print the result as selected by the command line flags,
+// -fixed and -region are only passed to the depth first search of the solver, the other
+// searches would print solutions breaking them, so they are rejected with these
+func checkConstraintFlags() error {
+	searches := []struct {
+		name string
+		set  bool
+	}{{"all", *printAll}, {"bidirectional", *bidirectional}, {"parallel", *parallel}, {"astar", *astar}}
+	for _, constraint := range []struct{ name, value string }{{"fixed", *fixedCells}, {"region", *region}} {
+		if constraint.value == "" {
+			continue
+		}
+		for _, search := range searches {
+			if search.set {
+				return fmt.Errorf("-%s is not supported by -%s", constraint.name, search.name)
+			}
+		}
+	}
+	return nil
+}
 
-	// start recursively search for the initial board from the goal (reverse direction!)
-	search(GOAL_BOARD)
+// returns false if it could not be solved, exits if the context is canceled by Ctrl-C
+func run(ctx context.Context, solver *Solver, start uint64) bool {
+	startTime := time.Now()
+	var result Result
+	var err error
+	// the block strategy only plays the standard puzzle, without constraints
+	var strategyPath []uint64
+	byStrategy := false
+	if *strategy && solver.FixedCells == 0 && solver.ValidCells == 0 {
+		strategyPath, byStrategy = StrategySolution(start, GOAL_BOARD)
+	}
+	// the search statistics and diagnostics are only collected by the solver itself
+	solverSearch := !*bidirectional && !*parallel && !*astar && !byStrategy
+	if byStrategy {
+		solver.Solution = strategyPath
+	} else if *bidirectional {
+		solver.Solution, err = SolveBidirectional(ctx, start, GOAL_BOARD, *maxBoards)
+	} else if *parallel {
+		solver.Solution, err = SolveParallel(ctx, start, GOAL_BOARD, *workers, *seed)
+	} else if *astar {
+		solver.Solution, err = SolveAStar(ctx, start, GOAL_BOARD, nil)
+	} else {
+		// the fixed pegs stay on the board in addition to the peg of the goal
+		result, err = solver.Solve(ctx, start, GOAL_BOARD|solver.FixedCells)
+	}
+	if !solverSearch {
+		result = Result{Path: solver.Solution, Solved: err == nil, Moves: max(len(solver.Solution)-1, 0), Elapsed: time.Since(startTime)}
+	}
+	if *printTime {
+		fmt.Fprintf(os.Stderr, "search took %v\n", time.Since(startTime))
+	}
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "interrupted")
+		if solverSearch {
+			PrintPartialStats(os.Stderr, solver, time.Since(startTime))
+		}
+		os.Exit(130)
+	}
+	if err != nil && !errors.Is(err, ErrNoSolution) {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	if *jsonState {
+		if err := WriteSolveState(os.Stdout, NewSolveState(start, GOAL_BOARD|solver.FixedCells, result)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return result.Solved
+	}
+	if err != nil {
+		if solver.ValidCells == 0 && !IsSolvable(start, GOAL_BOARD|solver.FixedCells) {
+			fmt.Println("no solution found (ruled out without searching by the peg count or the color invariant)")
+		} else {
+			fmt.Println("no solution found")
+		}
+		if solver.FailureDiagnostics && solverSearch {
+			PrintDeepestPath(solver.DeepestPath)
+		}
+		return false
+	}
 
 	// print the solution
-	PrintSolution()
+	if *printMoves {
+		PrintMoves(solver.Solution)
+	} else if *export {
+		fmt.Println(ExportSolution(solver.Solution))
+	} else if *summary {
+		PrintSummary(solver.Solution, *summaryFrames)
+	} else if *animate {
+		Animate(ctx, solver.Solution, *animateDelay)
+	} else if *critical {
+		PrintCriticalMoves(solver.Solution, *solveTimeout)
+	} else if *sheet {
+		if err := WriteSheet(os.Stdout, solver.Solution, *sheetSteps); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	} else if *markdown {
+		if err := WriteMarkdown(os.Stdout, solver.Solution); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	} else if *printCSV {
+		if err := WriteCSV(os.Stdout, solver.Solution); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	} else if *describe {
+		DescribeSolution(solver.Solution)
+	} else if *printFinal {
+		PrintFinal(solver.Solution)
+	} else {
+		if len(solver.Solution) == 1 {
+			fmt.Println("the start board already is the goal, no moves needed")
+		}
+		if *reverseOrder {
+			PrintSolution(Reversed(solver.Solution), boardsPerRow())
+		} else {
+			PrintSolution(solver.Solution, boardsPerRow())
+		}
+	}
+	if *longestSweep && solverSearch {
+		fmt.Printf("longest sweep: %d jump(s)\n", SweepCount(solver.Solution))
+	}
+	if *pegGolf && solverSearch {
+		fmt.Printf("moving pegs: %d\n", MovingPegCount(solver.Solution))
+	}
+	if *searchMode == "optimal" && solverSearch {
+		fmt.Printf("moves: %d (%d jumps)\n", MoveCount(solver.Solution), len(solver.Solution)-1)
+	}
+	if *clustered && solverSearch {
+		fmt.Printf("spread: %d\n", PathSpread(solver.Solution))
+	}
+	if *printForced {
+		fmt.Printf("forced moves: %d of %d\n", ForcedMoveCount(solver.Solution), len(solver.Solution)-1)
+	}
+	if solver.Scoring != nil && solverSearch {
+		fmt.Printf("score: %d\n", solver.Scoring.Score(solver.Solution))
+	}
+	if solver.CollectStats && solverSearch {
+		PrintStats(solver.Stats)
+	}
+	return true
+}
 
+// find and print all solutions for the given start board, returns false if there is none
+func runAll(start uint64) bool {
+	if !*uniqueSolutions {
+		// stream the solutions instead of collecting all of them
+		count := 0
+		EnumerateSolutions(start, GOAL_BOARD, func(solution []uint64) bool {
+			count++
+			fmt.Printf("solution %d:\n", count)
+			if *printMoves {
+				PrintMoves(solution)
+			} else {
+				PrintSolution(solution, boardsPerRow())
+			}
+			return count != *maxSolutions
+		})
+		if count == 0 {
+			fmt.Println("no solution found")
+		}
+		return count > 0
+	}
+	solutions := MinLengthSolutions(start, GOAL_BOARD, *maxSolutions)
+	if len(solutions) == 0 {
+		fmt.Println("no solution found")
+		return false
+	}
+	// grouping the symmetric solutions needs all of them at once
+	solutions, sizes := CollapseSymmetric(solutions)
+	for i, solution := range solutions {
+		fmt.Printf("solution %d (%d symmetric solutions):\n", i+1, sizes[i])
+		if *printMoves {
+			PrintMoves(solution)
+		} else {
+			PrintSolution(solution, boardsPerRow())
+		}
+	}
+	return true
+}
+
+// the outcome of a search
+type Result struct {
+	// the boards of the solution (the same as Solver.Solution), nil if there is none
+	Path []uint64
+	// whether a solution was found
+	Solved bool
+	// the number of moves of the solution and their indices (see MoveIndex)
+	Moves       int
+	MoveIndices []uint8
+	// the number of boards visited by the search, the time it took and the approximate
+	// memory used by its seen boards (see EstimatedMemory)
+	NodesVisited    uint64
+	Elapsed         time.Duration
+	EstimatedMemory uint64
+	// the score of the solution if the solver has a Scoring
+	Score int
+}
+
+// a step of a solution: a board and the move made on it
+type Step struct {
+	Board uint64
+	Move  Move
+}
+
+// get the steps of the solution in playing order, one per move (none if there is no
+// solution), e.g. for animating it
+func (r Result) Steps() []Step {
+	return SolutionSteps(r.Path)
+}
+
+// get the steps of a solution path in playing order (see Result.Steps)
+func SolutionSteps(path []uint64) []Step {
+	steps := make([]Step, 0, len(path))
+	for i, move := range SolutionMoves(path) {
+		steps = append(steps, Step{path[i], move})
+	}
+	return steps
+}
+
+// find a solution leading from the start to the goal board
+// the boards of the solution are stored in Solution (nil if there is none) and
+// returned as part of the result together with some statistics of the search
+// returns ErrNoSolution if there is none, or the error of the context if it
+// is cancelled before the search is done
+func (s *Solver) Solve(ctx context.Context, start uint64, goal uint64) (Result, error) {
+	startTime := time.Now()
+	err := s.solve(ctx, start, goal)
+	result := Result{
+		Path:            s.Solution,
+		Solved:          err == nil,
+		NodesVisited:    s.nodes.Load(),
+		Elapsed:         time.Since(startTime),
+		EstimatedMemory: s.EstimatedMemory(),
+	}
+	if result.Solved {
+		result.Moves = len(s.Solution) - 1
+		_, result.MoveIndices = CompressSolution(s.Solution)
+		if s.Scoring != nil {
+			result.Score = s.Scoring.Score(s.Solution)
+		}
+	}
+	return result, err
+}
+
+// do the work of Solve
+func (s *Solver) solve(ctx context.Context, start uint64, goal uint64) error {
+	valid := s.Board.ValidCells
+	if s.ValidCells != 0 {
+		valid &= s.ValidCells
+	}
+	if s.MaskInvalidCells {
+		start &= valid
+		goal &= valid
+	} else if err := checkCells(start, valid); err != nil {
+		return err
+	} else if err := checkCells(goal, valid); err != nil {
+		return err
+	}
+	s.Reset()
+	s.target = start
+	s.targetPegs = PegCount(start)
+	s.ctx = ctx
+	s.goal.Store(goal)
+	s.nodes.Store(0)
+	s.seen.Store(0)
+	s.current.Store(goal)
+	s.deepest.Store(0)
+	// the reverse search begins with the goal, the searches for the other objectives go
+	// forward from the start
+	s.originPegs = PegCount(goal)
+	if s.Objective != FirstSolution {
+		s.originPegs = PegCount(start)
+	}
+	// copy the progress counter into the statistics once the search is done
+	defer func() {
+		s.seen.Store(uint64(len(s.seenBoards)))
+		s.Stats.NodesVisited = s.nodes.Load()
+		s.Stats.EstimatedMemory = s.EstimatedMemory()
+	}()
+	if empty := s.FixedCells &^ start; empty != 0 {
+		return fmt.Errorf("fixed cell %s is empty in the start board", cellName(bits.TrailingZeros64(empty)))
+	}
+	s.applyConstraints(s.constraints(valid))
+	// nothing to do if the start board already is the goal (the search would never find it)
+	if start == goal {
+		s.Solution = []uint64{start}
+		return nil
+	}
+	// the fixed pegs can not be removed
+	if s.FixedCells&^goal != 0 || !s.Board.IsSolvable(start, goal) {
+		return ErrNoSolution
+	}
+	if s.Objective == MaxScore && s.Scoring == nil {
+		return errors.New("the MaxScore objective needs a Scoring")
+	}
+	if s.Objective != FirstSolution {
+		switch s.Objective {
+		case LongestSweep:
+			s.searchLongestSweep(start)
+		case MaxScore:
+			s.searchMaxScore(start)
+		case MostClustered:
+			s.searchClustered(start)
+		case PegGolf:
+			s.searchPegGolf(start)
+		case FewestMoves:
+			s.searchFewestMoves(start)
+		}
+		if s.err != nil {
+			return s.err
+		}
+		if s.Solution == nil {
+			return ErrNoSolution
+		}
+		return nil
+	}
+	if s.useTable() {
+		path, unsolvable := s.Table.lookup(start, goal)
+		if unsolvable {
+			return ErrNoSolution
+		}
+		if path != nil {
+			s.Solution = path
+			return nil
+		}
+	}
+	// start recursively search for the start board from the goal (reverse direction!)
+	s.started = time.Now()
+	s.lastProgress = s.started
+	s.symmetries, s.budget = nil, 0
+	if symmetries := s.searchSymmetries(start, goal); s.Symmetry {
+		s.symmetries = symmetries
+	} else if len(symmetries) > 0 {
+		s.budget = s.SymmetryFallback
+	}
+	s.Solution = s.searchFrom(goal)
+	if s.err == errNodeBudget {
+		// the seen boards are keyed differently with symmetry pruning, so start over
+		s.Stats.SymmetryRestart = true
+		s.err, s.budget = nil, 0
+		s.seenBoards = map[uint64]bool{}
+		s.currentPath = s.currentPath[:0]
+		s.symmetries = s.searchSymmetries(start, goal)
+		s.Solution = s.searchFrom(goal)
+	}
+	if s.Progress != nil {
+		s.reportProgress(true)
+	}
+	if s.Solution != nil {
+		if s.useTable() {
+			s.Table.storeSolution(s.Solution)
+		}
+		return nil
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if s.useTable() {
+		s.Table.storeUnsolvable(start, goal)
+	}
+	return ErrNoSolution
+}
+
+// get the number of boards visited by the running (or last) search
+// this is safe to call from another goroutine while the search is running
+func (s *Solver) NodesVisited() uint64 {
+	return s.nodes.Load()
+}
+
+// the approximate number of bytes of an entry of the seen boards: the key and the value of
+// a map entry take 16 bytes, the map needs another 10 to 20 bytes depending on how full it is
+const seenBoardBytes = 30
+
+// get the approximate memory used by the seen boards of the last or the running search
+// this is safe to call from another goroutine while the search is running, but only
+// updated every contextCheckInterval visited boards
+func (s *Solver) EstimatedMemory() uint64 {
+	return s.seen.Load() * seenBoardBytes
+}
+
+// get the depth of the running (or last) search, i.e. the number of moves the currently
+// visited board is away from the goal - since every reverse move adds a peg, this is
+// the peg count of the board minus the peg count of the goal
+// this is safe to call from another goroutine while the search is running
+func (s *Solver) Depth() int {
+	return PegCount(s.current.Load()) - PegCount(s.goal.Load())
+}
+
+// get the deepest depth reached by the running (or last) search, i.e. the largest number
+// of moves a visited board was away from the board the search began with - unlike Depth,
+// this does not go back when the search returns from a dead end
+// this is safe to call from another goroutine while the search is running
+func (s *Solver) MaxDepth() int {
+	return int(s.deepest.Load())
+}
+
+// make the board the currently visited board of the search (see Depth and MaxDepth)
+func (s *Solver) visit(board uint64) {
+	s.current.Store(board)
+	depth := int64(PegCount(board) - s.originPegs)
+	if depth < 0 {
+		depth = -depth
+	}
+	if depth > s.deepest.Load() {
+		s.deepest.Store(depth)
+	}
+}
+
+// check if the transposition table can be used: its entries are only valid for searches
+// without fixed cells, region, constraints and pruning
+func (s *Solver) useTable() bool {
+	return s.Table != nil && s.FixedCells == 0 && s.ValidCells == 0 && s.Constraints == nil && s.Prune == nil
+}
+
+// the context of a search is checked every that many visited boards
+const contextCheckInterval = 1 << 14
+
+// run the reverse search from the goal board, recursive or iterative (see Iterative)
+func (s *Solver) searchFrom(goal uint64) []uint64 {
+	if s.Iterative {
+		return s.searchIterative(goal)
+	}
+	return s.search(goal)
 }
 
 // do the calculation recursively by starting from
-// the "GOAL_BOARD" and doing moves in reverse
-func search(board uint64) bool {
+// the goal board and doing moves in reverse
+// returns the path from the start board to the given board (in playing order),
+// or nil if the start board can not be reached
+func (s *Solver) search(board uint64) []uint64 {
+	moves, ok := s.enter(board)
+	if !ok {
+		return nil
+	}
+	if s.FailureDiagnostics {
+		s.pushPath(board)
+		defer s.popPath()
+	}
 	// for all possible moves
-	for _, move := range Moves {
+	for _, move := range moves {
 		// check if the move is valid
 		// Note: we place "two ball" check first since it is more
 		// likely to fail. This saves about 20% in run time (!)
 		if (move.before&board) == 0 && (move.after&board) != 0 {
 			// calculate the board after this move was applied
 			newBoard := board ^ move.all
-			// only continue processing if we have not seen this board before
-			if !seenBoards[newBoard] {
-				seenBoards[newBoard] = true
-				// check if the initial board is reached
-				if newBoard == INITIAL_BOARD || search(newBoard) {
-					Solution = append(Solution, board)
-					return true
+			if !s.isNewBoard(board, newBoard, move) {
+				continue
+			}
+			// check if the start board is reached - the path is built while
+			// returning from the recursion, so it grows towards the goal board
+			if newBoard == s.target {
+				// capacity is based on the max. number of moves (one peg is removed by each)
+				return append(make([]uint64, 0, s.targetPegs), newBoard, board)
+			}
+			if s.isCandidate(newBoard) {
+				if path := s.search(newBoard); path != nil {
+					return append(path, board)
 				}
+				s.failed(newBoard)
 			}
 		}
 	}
-	return false
+	return nil
+}
+
+// count a board visited by the search and check the context and the budget, returns the
+// moves to try on the board or false if the search has to stop
+func (s *Solver) enter(board uint64) ([]Move, bool) {
+	s.visit(board)
+	if nodes := s.nodes.Add(1); nodes%contextCheckInterval == 0 {
+		if s.ctx.Err() != nil {
+			s.err = s.ctx.Err()
+		} else if s.budget > 0 && nodes >= s.budget {
+			s.err = errNodeBudget
+		}
+		if s.Progress != nil {
+			s.reportProgress(false)
+		}
+		s.seen.Store(uint64(len(s.seenBoards)))
+		s.checkSeenBoards()
+	}
+	if s.err != nil {
+		return nil, false
+	}
+	if s.CollectStats {
+		s.recordStats(board)
+	}
+	if s.Score != nil {
+		return s.scoredMoves(board), true
+	}
+	return s.moves, true
+}
+
+// check if the board reached by a reverse move has to be looked at: it has to be allowed by
+// the constraints and not seen before, marks it as seen
+func (s *Solver) isNewBoard(board uint64, newBoard uint64, move Move) bool {
+	// check the constraints before the board is marked as seen, since it may
+	// still be reached by another move if they reject this one
+	if s.boardConstraints != nil && !s.allows(newBoard, move) {
+		return false
+	}
+	// only continue processing if we have not seen this board before
+	key := newBoard
+	if s.symmetries != nil {
+		key = s.seenKey(newBoard)
+	}
+	if s.seenBoards[key] {
+		return false
+	}
+	s.seenBoards[key] = true
+	return true
+}
+
+// check if the search has to continue from a new board (other than the start board)
+func (s *Solver) isCandidate(board uint64) bool {
+	return PegCount(board) < s.targetPegs && (s.Prune == nil || !s.Prune(board)) &&
+		(s.dead == nil || !s.dead.contains(board))
+}
+
+// the start board was not found from the board, share it with the other workers
+func (s *Solver) failed(board uint64) {
+	// a canceled search may not have looked at all boards before the board
+	if s.dead != nil && s.err == nil {
+		s.dead.add(board)
+	}
+}
+
+// get the middle cell of three cells that are consecutive in a row or a column - on a torus
+// (see Variant.Torus) they may also wrap around the edges of the 7 x 7 layout, on a board
+// with diagonal lines (see Variant.Diagonal) also be on a diagonal. Returns false if the
+// cells do not form such a line
+func (b *Board) lineMiddle(cells uint64) (int, bool) {
+	return lineMiddle(cells, b.torus, b.diagonal)
+}
+
+// get the middle cell of a line of three cells, with or without the lines wrapping around
+// the edges and running along the diagonals (see Board.lineMiddle)
+func lineMiddle(cells uint64, torus bool, diagonal bool) (int, bool) {
+	// the cells in ascending order
+	first := bits.TrailingZeros64(cells)
+	last := 63 - bits.LeadingZeros64(cells)
+	middle := bits.TrailingZeros64(cells &^ (1 << first))
+	if bits.OnesCount64(cells) != 3 {
+		return 0, false
+	}
+	if (middle == first+1 && last == first+2 && first/7 == last/7) || (middle == first+7 && last == first+14) {
+		return middle, true
+	}
+	if diagonal && middle == first+8 && last == first+16 && first%7 <= 4 {
+		return middle, true
+	}
+	if !torus {
+		return 0, false
+	}
+	// the columns 0, 1 and 6 of a row have column 0 in the middle, the columns 0, 5 and 6
+	// column 6 (the same for the rows of a column)
+	if (last == first+6 && first/7 == last/7 && middle == first+1) || (last == first+42 && middle == first+7) {
+		return first, true
+	}
+	if (last == first+6 && first/7 == last/7 && middle == first+5) || (last == first+42 && middle == first+35) {
+		return last, true
+	}
+	return 0, false
+}
+
+// generate the two possible moves (one for each direction) of every line of three cells,
+// each distinct move only once
+func generateMoves(triples [][3]int) []Move {
+	moves := make([]Move, 0, 2*len(triples))
+	for _, t := range triples {
+		moves = createMoves(t[0], t[1], t[2], moves)
+	}
+	// a move given twice would count every solution using it twice (see SuccessorCollisions)
+	unique := moves[:0]
+	for _, move := range moves {
+		if !slices.Contains(unique, move) {
+			unique = append(unique, move)
+		}
+	}
+	return unique
+}
+
+// find all lines of three consecutive valid cells of a layout (a mask of the valid cells),
+// first in west-east direction and then in north-south direction (ordered by their first
+// cell) - every line holds the two moves jumping over its middle cell, one in each direction,
+// so there are half as many lines as moves
+func MoveTriples(validCells uint64) [][3]int {
+	var triples [][3]int
+	valid := func(cell int) bool { return (validCells & (1 << cell)) != 0 }
+	for cell := 0; cell < 49; cell++ {
+		if cell%7 <= 4 && valid(cell) && valid(cell+1) && valid(cell+2) {
+			triples = append(triples, [3]int{cell, cell + 1, cell + 2})
+		}
+	}
+	for cell := 0; cell < 35; cell++ {
+		if valid(cell) && valid(cell+7) && valid(cell+14) {
+			triples = append(triples, [3]int{cell, cell + 7, cell + 14})
+		}
+	}
+	return triples
+}
+
+// apply a move in forward direction, i.e. the way the game is actually played:
+// the two pegs in "before" must be present and the "after" slot must be a hole
+// returns false (and the unchanged board) if the move is not legal on the board
+func Apply(board uint64, move Move) (uint64, bool) {
+	if (board&move.before) != move.before || (board&move.after) != 0 {
+		return board, false
+	}
+	return board ^ move.all, true
+}
+
+// check that a path is a solution leading from the start to the goal board
+// by legal moves, the error describes the first problem found
+func VerifySolution(start uint64, goal uint64, path []uint64) error {
+	if len(path) == 0 || path[0] != start {
+		return fmt.Errorf("solution does not begin with the start board")
+	}
+	for i := 1; i < len(path); i++ {
+		move := moveBetween(path[i-1], path[i])
+		if MoveIndex(move) < 0 {
+			return fmt.Errorf("step %d is not a move", i)
+		}
+		if _, ok := Apply(path[i-1], move); !ok {
+			return fmt.Errorf("step %d is not a legal move", i)
+		}
+	}
+	if path[len(path)-1] != goal {
+		return fmt.Errorf("solution does not end with the goal board")
+	}
+	return nil
+}
+
+// get all moves that can be applied (in forward direction) on the board of the selected
+// variant
+func LegalMoves(board uint64) []Move {
+	return defaultBoard.LegalMoves(board)
+}
+
+// get all moves of the board that can be applied (in forward direction) on a board
+func (b *Board) LegalMoves(board uint64) []Move {
+	var moves []Move
+	// every move ends in a hole, so only the moves around the holes have to be checked
+	for holes := b.ValidCells &^ board; holes != 0; holes &= holes - 1 {
+		cell := bits.TrailingZeros64(holes)
+		for _, i := range b.movesByCell[cell] {
+			move := b.Moves[i]
+			// each move is only considered for its destination
+			if move.after != 1<<cell {
+				continue
+			}
+			if _, ok := Apply(board, move); ok {
+				moves = append(moves, move)
+			}
+		}
+	}
+	return moves
+}
+
+// count the legal moves on a board of the selected variant without collecting them
+func Mobility(board uint64) int {
+	return defaultBoard.Mobility(board)
+}
+
+// count the legal moves of the board on a board without collecting them
+func (b *Board) Mobility(board uint64) int {
+	count := 0
+	for holes := b.ValidCells &^ board; holes != 0; holes &= holes - 1 {
+		cell := bits.TrailingZeros64(holes)
+		for _, i := range b.movesByCell[cell] {
+			// the destination is a hole, both other cells need a peg
+			move := b.Moves[i]
+			if move.after == 1<<cell && move.before&board == move.before {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// build the index of the moves involving each cell (see Board.movesByCell)
+func indexMovesByCell(moves []Move) [49][]int {
+	var index [49][]int
+	for i, move := range moves {
+		for all := move.all; all != 0; all &= all - 1 {
+			cell := bits.TrailingZeros64(all)
+			index[cell] = append(index[cell], i)
+		}
+	}
+	return index
+}
+
+// check if a move can be applied in reverse direction on the board, i.e. if the board
+// can be the result of the move: the "after" peg must be present and the two "before"
+// slots must be empty (this is the check the search does for every move)
+func IsReverseLegal(move Move, board uint64) bool {
+	return (move.before&board) == 0 && (move.after&board) != 0
+}
+
+// undo a move, i.e. apply it in reverse direction
+// returns false (and the unchanged board) if the board can not be the result of the move
+func Undo(board uint64, move Move) (uint64, bool) {
+	if !IsReverseLegal(move, board) {
+		return board, false
+	}
+	return board ^ move.all, true
+}
+
+// reconstruct the move that leads from one board to the next
+func moveBetween(prev uint64, next uint64) Move {
+	var move Move
+	move.all = prev ^ next
+	move.after = move.all & next
+	move.before = move.all & prev
+	return move
+}
+
+// reconstruct the moves of a solution path (in playing order)
+func SolutionMoves(path []uint64) []Move {
+	moves := make([]Move, 0, len(path))
+	for i := 1; i < len(path); i++ {
+		moves = append(moves, moveBetween(path[i-1], path[i]))
+	}
+	return moves
+}
+
+// get the first (up to) n moves of a solution leading from the board to the goal, e.g. as a
+// hint. ErrNoSolution is returned if the goal can not be reached, the error of the context if
+// the search is canceled before (so pass a context with a deadline, the search can take long)
+func PreviewMoves(ctx context.Context, board uint64, goal uint64, n int) ([]Move, error) {
+	solver := NewSolver()
+	solver.SetOrder("random")
+	if _, err := solver.Solve(ctx, board, goal); err != nil {
+		return nil, err
+	}
+	moves := SolutionMoves(solver.Solution)
+	if len(moves) > n {
+		moves = moves[:n]
+	}
+	return moves, nil
+}
+
+// check the internal consistency of a move: "after" has to be a single peg, "before"
+// two pegs, "all" the three of them, and the three cells have to be consecutive cells of
+// a row or column with "after" at one end
+func ValidateMove(move Move) error {
+	if bits.OnesCount64(move.after) != 1 {
+		return fmt.Errorf("move has %d destination pegs instead of 1", bits.OnesCount64(move.after))
+	}
+	if bits.OnesCount64(move.before) != 2 {
+		return fmt.Errorf("move has %d removed pegs instead of 2", bits.OnesCount64(move.before))
+	}
+	if move.all != move.after|move.before || bits.OnesCount64(move.all) != 3 {
+		return fmt.Errorf("move does not involve exactly its destination and removed pegs")
+	}
+	middle, ok := defaultBoard.lineMiddle(move.all)
+	if !ok {
+		// the cells in ascending order
+		first := bits.TrailingZeros64(move.all)
+		last := 63 - bits.LeadingZeros64(move.all)
+		second := bits.TrailingZeros64(move.all &^ (1 << first))
+		return fmt.Errorf("move cells %d, %d and %d are not consecutive in a row or column", first, second, last)
+	}
+	if move.after == 1<<middle {
+		return fmt.Errorf("move destination %d is the jumped over cell", middle)
+	}
+	return nil
 }
 
 // create the two possible moves for the three added pegs
 // (this function assumes that the pegs are in one continuous line)
 func createMoves(bit1 int, bit2 int, bit3 int, moves []Move) []Move {
+	checkCell(bit1)
+	checkCell(bit2)
+	checkCell(bit3)
 	var newmove Move
 	newmove.after = 1 << bit1
 	newmove.before = (1 << bit2) | (1 << bit3)
@@ -132,22 +1483,26 @@ func createMoves(bit1 int, bit2 int, bit3 int, moves []Move) []Move {
 	return moves
 }
 
-// print the found solution
-func PrintSolution() {
+// print the found solution (or any other sequence of boards),
+// highlighting the changes between consecutive boards
+func PrintSolution(boards []uint64, perRow int) {
+	if perRow < 1 {
+		perRow = 1
+	}
 
-	for i := 0; i < len(Solution); i++ {
-		// loop over all 7 rows
+	for i := 0; i < len(boards); i++ {
+		// loop over all 7 rows (and the border)
 		var k int
-		for m := 0; m < 7; m++ {
-			// print 16 steps in 1 row
-			for k = 0; k < 16; k++ {
+		for m := 0; m < boardHeight(); m++ {
+			// print perRow steps in 1 row
+			for k = 0; k < perRow; k++ {
 				//fmt.Printf("i: %d, m: %d, k: %d", i, m, k)
 				previous := i + k - 1
 				if previous < 0 {
 					previous = 0
 				}
-				printLine(Solution[i+k], Solution[previous], m)
-				if (i + k) == len(Solution)-1 {
+				printStyledLine(boards[i+k], boards[previous], m)
+				if (i + k) == len(boards)-1 {
 					k++
 					break
 				}
@@ -160,37 +1515,103 @@ func PrintSolution() {
 	}
 }
 
+// the number of boards printed side by side, for -per-row 0 as many as fit into the
+// terminal width given by $COLUMNS (80 if it is not set)
+func boardsPerRow() int {
+	if *perRow > 0 {
+		return *perRow
+	}
+	width, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || width <= 0 {
+		width = 80
+	}
+	// every board is followed by 3 spaces except for the last one
+	return (width + 3) / (boardWidth() + 3)
+}
+
+// print only the last board of the found solution together with its peg count
+func PrintFinal(solution []uint64) {
+	board := solution[len(solution)-1]
+	printBoard(board, board)
+	fmt.Printf("%d peg(s) remaining after %d moves\n", PegCount(board), len(solution)-1)
+	if cell, ok := FinalPegCell(solution); ok {
+		row, col := bitToCoord(cell)
+		fmt.Printf("the last peg is in %s (row %d, column %d)\n", cellName(cell), row+1, col+1)
+	}
+}
+
+// print a single board, highlighting the changes to the previous board
+// (pass the board again as previous board to not highlight anything)
+func printBoard(board uint64, prev_board uint64) {
+	for m := 0; m < boardHeight(); m++ {
+		printStyledLine(board, prev_board, m)
+		fmt.Println()
+	}
+}
+
+// count the pegs on a board
+func PegCount(board uint64) int {
+	return bits.OnesCount64(board)
+}
+
+// get the jumped over cell (as a mask) of the move between two boards, in either direction
+// (for boards printed in reverse order), 0 if the boards do not differ by a single move
+func jumpedCell(prev uint64, next uint64) uint64 {
+	for _, move := range []Move{moveBetween(prev, next), moveBetween(next, prev)} {
+		if ValidateMove(move) == nil {
+			_, over, _ := moveCells(move)
+			return 1 << over
+		}
+	}
+	return 0
+}
+
+// whether printLine highlights the cells with ANSI colors (see -no-color)
+var colored = true
+
 // print one line of the board
 // first argument: board to print
 // second argument: previous board - the function will highlight any changes made by a move
+// (the jumped over cell in yellow, the others in red if they hold a peg and blue if not)
 // pass the board from the first argument again to not highlight any changes
 // third argument: line number to print
-func printLine(board uint64, prev_board uint64, line int) {
-	const colorReset = "\033[0m"
-	const colorRed = "\033[31m"
-	const colorBlue = "\033[34m"
-	const colorGrey = "\033[37m"
-	const colorWhite = "\033[97m"
+func (b *Board) printLine(board uint64, prev_board uint64, line int) {
+	colorReset, colorRed, colorBlue := "\033[0m", "\033[31m", "\033[34m"
+	colorYellow, colorGrey, colorWhite := "\033[33m", "\033[37m", "\033[97m"
+	if !colored {
+		colorReset, colorRed, colorBlue, colorYellow, colorGrey, colorWhite = "", "", "", "", "", ""
+	}
+
+	// the jumped over cell can only be told apart from the moved peg by the whole move
+	over := jumpedCell(prev_board, board)
 
 	// loop over all cells (the board is 7 x 7)
+	checkCell(7*line + 6)
 	var cell uint64 = 1 << (7 * line) // move to first cell in the line
 	for i := 0; i < 7; i++ {
-		validCell := (cell & VALID_BOARD_CELLS) != 0
+		validCell := (cell & b.ValidCells) != 0
 		if validCell {
-			if (cell & board) != 0 {
+			if cell == over {
+				fmt.Print(colorYellow)
+				if (cell & board) != 0 {
+					fmt.Print(boardStyle.Peg + colorReset)
+				} else {
+					fmt.Print(boardStyle.Hole + colorReset)
+				}
+			} else if (cell & board) != 0 {
 				if (cell & prev_board) == 0 {
-					fmt.Printf(colorRed)
+					fmt.Print(colorRed)
 				} else {
-					fmt.Printf(colorWhite)
+					fmt.Print(colorWhite)
 				}
-				fmt.Printf("X" + colorReset)
+				fmt.Print(boardStyle.Peg + colorReset)
 			} else {
 				if (cell & prev_board) != 0 {
-					fmt.Printf(colorBlue)
+					fmt.Print(colorBlue)
 				} else {
-					fmt.Printf(colorGrey)
+					fmt.Print(colorGrey)
 				}
-				fmt.Printf("0" + colorReset)
+				fmt.Print(boardStyle.Hole + colorReset)
 			}
 		} else {
 			fmt.Printf(" ")
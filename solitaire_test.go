@@ -0,0 +1,399 @@
+package solitaire
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// the seed of the benchmarks, one with a short search of the standard board
+const benchmarkSeed = 2
+
+// solve the standard board with the moves shuffled by benchmarkSeed and the solver set up by
+// configure, reporting the number of seen boards of the search
+func benchmarkSolve(b *testing.B, configure func(s *Solver)) {
+	var seen int
+	for i := 0; i < b.N; i++ {
+		solver := NewSolver()
+		solver.Rand = rand.New(rand.NewSource(benchmarkSeed))
+		solver.SetOrder("random")
+		configure(solver)
+		if _, err := solver.Solve(context.Background(), INITIAL_BOARD, GOAL_BOARD); err != nil {
+			b.Fatal(err)
+		}
+		seen = len(solver.seenBoards)
+	}
+	b.ReportMetric(float64(seen), "seen-boards")
+}
+
+func BenchmarkSolveSymmetry(b *testing.B) {
+	benchmarkSolve(b, func(s *Solver) { s.Symmetry = true })
+}
+
+func BenchmarkSolvePlain(b *testing.B) {
+	benchmarkSolve(b, func(s *Solver) { s.Symmetry = false })
+}
+
+// the time per visited board is independent of how many boards the seed makes the search visit
+func BenchmarkSolveNsPerNode(b *testing.B) {
+	var nodes uint64
+	for i := 0; i < b.N; i++ {
+		solver := NewSolver()
+		solver.Rand = rand.New(rand.NewSource(benchmarkSeed))
+		solver.SetOrder("random")
+		if _, err := solver.Solve(context.Background(), INITIAL_BOARD, GOAL_BOARD); err != nil {
+			b.Fatal(err)
+		}
+		nodes += solver.NodesVisited()
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(nodes), "ns/node")
+}
+
+// applying a move and then undoing it gives back the board, and the other way round, on
+// every board of the layout and with every move
+func FuzzApplyUndo(f *testing.F) {
+	f.Add(INITIAL_BOARD, 0)
+	f.Add(GOAL_BOARD, 5)
+	f.Add(uint64(0), 1)
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		f.Add(random.Uint64()&defaultBoard.ValidCells, random.Intn(len(defaultBoard.Moves)))
+	}
+	f.Fuzz(func(t *testing.T, board uint64, index int) {
+		board &= defaultBoard.ValidCells
+		move := defaultBoard.Moves[uint(index)%uint(len(defaultBoard.Moves))]
+		if next, ok := Apply(board, move); ok {
+			if back, ok := Undo(next, move); !ok || back != board {
+				t.Errorf("%s applied to %#x and undone gives %#x, %v", MoveDescription(move), board, back, ok)
+			}
+		} else if next != board {
+			t.Errorf("%s is not legal on %#x but changes it", MoveDescription(move), board)
+		}
+		if previous, ok := Undo(board, move); ok {
+			if back, ok := Apply(previous, move); !ok || back != board {
+				t.Errorf("%s undone on %#x and applied gives %#x, %v", MoveDescription(move), board, back, ok)
+			}
+		} else if previous != board {
+			t.Errorf("%s can not be undone on %#x but changes it", MoveDescription(move), board)
+		}
+	})
+}
+
+// every line of three cells gives two moves, one in each direction: both jump over the middle
+// cell and each lands where the other starts
+func TestGenerateMovesBothDirections(t *testing.T) {
+	triples := MoveTriples(defaultBoard.ValidCells)
+	moves := generateMoves(triples)
+	byCells := map[uint64][]Move{}
+	for _, move := range moves {
+		byCells[move.all] = append(byCells[move.all], move)
+	}
+	for _, triple := range triples {
+		all := uint64(1)<<triple[0] | 1<<triple[1] | 1<<triple[2]
+		pair := byCells[all]
+		if len(pair) != 2 {
+			t.Errorf("line %v has %d moves, want 2", triple, len(pair))
+			continue
+		}
+		if pair[0].after == pair[1].after || pair[0].after|pair[1].after != pair[0].before^pair[1].before ||
+			pair[0].before&pair[1].before != 1<<triple[1] {
+			t.Errorf("the moves %s and %s of line %v are not the two directions",
+				MoveDescription(pair[0]), MoveDescription(pair[1]), triple)
+		}
+		for _, move := range pair {
+			if move.after|move.before != all || move.after&move.before != 0 {
+				t.Errorf("move %s of line %v does not cover the line", MoveDescription(move), triple)
+			}
+		}
+	}
+	if len(byCells) != len(triples) {
+		t.Errorf("the moves cover %d lines, want the %d lines of the board", len(byCells), len(triples))
+	}
+	if len(moves) != len(defaultBoard.Moves) {
+		t.Errorf("got %d moves, the board has %d", len(moves), len(defaultBoard.Moves))
+	}
+}
+
+// every solution of the standard puzzle removes one peg per move, from 32 pegs with the
+// center empty to a single peg in the center, so it has 31 moves
+func TestSolveStandardPuzzle(t *testing.T) {
+	if CenterCell() != 24 || GOAL_BOARD != 1<<CenterCell() || INITIAL_BOARD != defaultBoard.ValidCells&^GOAL_BOARD {
+		t.Fatalf("the standard puzzle is not from the full board without d4 to a single peg in d4")
+	}
+	for _, seed := range []int64{2, 5, 7} {
+		solver := NewSolver()
+		solver.Rand = rand.New(rand.NewSource(seed))
+		solver.SetOrder("random")
+		result, err := solver.Solve(context.Background(), INITIAL_BOARD, GOAL_BOARD)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if err := VerifySolution(INITIAL_BOARD, GOAL_BOARD, result.Path); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+		if result.Moves != 31 || len(result.Path) != 32 {
+			t.Errorf("seed %d: the solution has %d moves and %d boards, want 31 and 32", seed, result.Moves, len(result.Path))
+		}
+		for i, board := range result.Path {
+			if PegCount(board) != 32-i {
+				t.Errorf("seed %d: board %d has %d pegs, want %d", seed, i, PegCount(board), 32-i)
+				break
+			}
+		}
+	}
+}
+
+// a move can be undone right after it was made, but not on the board it was made on
+func TestIsReverseLegal(t *testing.T) {
+	for _, board := range []uint64{INITIAL_BOARD, boardAfterMoves(t, 10), boardAfterMoves(t, 25)} {
+		for _, move := range LegalMoves(board) {
+			next, _ := Apply(board, move)
+			if !IsReverseLegal(move, next) {
+				t.Errorf("%s can not be undone after it was made", MoveDescription(move))
+			}
+			if IsReverseLegal(move, board) {
+				t.Errorf("%s can be undone before it was made", MoveDescription(move))
+			}
+		}
+	}
+	// on the goal board only the four moves into d4 can be undone
+	undoable := 0
+	for _, move := range defaultBoard.Moves {
+		if IsReverseLegal(move, GOAL_BOARD) {
+			undoable++
+			if _, _, to := moveCells(move); to != CenterCell() {
+				t.Errorf("%s can be undone on the goal board", MoveDescription(move))
+			}
+		}
+	}
+	if undoable != 4 {
+		t.Errorf("%d moves can be undone on the goal board, want 4", undoable)
+	}
+}
+
+func TestValidateMove(t *testing.T) {
+	for _, move := range defaultBoard.Moves {
+		if err := ValidateMove(move); err != nil {
+			t.Errorf("%s: %v", MoveDescription(move), err)
+		}
+	}
+	cells := func(cells ...int) uint64 {
+		var mask uint64
+		for _, cell := range cells {
+			mask |= 1 << cell
+		}
+		return mask
+	}
+	invalid := []struct {
+		name string
+		move Move
+	}{
+		{"two destinations", Move{after: cells(10, 24), before: cells(17, 31), all: cells(10, 17, 24, 31)}},
+		{"one removed peg", Move{after: cells(10), before: cells(17), all: cells(10, 17)}},
+		{"all not the union", Move{after: cells(10), before: cells(17, 24), all: cells(10, 17, 31)}},
+		{"overlapping destination", Move{after: cells(17), before: cells(17, 24), all: cells(17, 24)}},
+		{"not in a line", Move{after: cells(10), before: cells(17, 25), all: cells(10, 17, 25)}},
+		{"gap in the line", Move{after: cells(3), before: cells(17, 24), all: cells(3, 17, 24)}},
+		{"wrapping into the next row", Move{after: cells(19), before: cells(20, 21), all: cells(19, 20, 21)}},
+		{"destination in the middle", Move{after: cells(17), before: cells(10, 24), all: cells(10, 17, 24)}},
+	}
+	for _, test := range invalid {
+		if err := ValidateMove(test.move); err == nil {
+			t.Errorf("%s: ValidateMove accepts %+v", test.name, test.move)
+		}
+	}
+}
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// the canonical solution must never change, it is recorded in testdata/canonical.txt (one
+// move per line, in the format of -moves); run the test with -update to rewrite it
+func TestSolveCanonicalGolden(t *testing.T) {
+	path, err := SolveCanonical()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var text strings.Builder
+	for _, move := range SolutionMoves(path) {
+		text.WriteString(MoveString(move) + "\n")
+	}
+	golden := filepath.Join("testdata", "canonical.txt")
+	if *updateGolden {
+		if err := os.WriteFile(golden, []byte(text.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text.String() != string(want) {
+		t.Errorf("the canonical solution changed:\n%s\nwant:\n%s", text.String(), want)
+	}
+}
+
+// every line holds two moves, and its cells are valid and consecutive in a row or column
+func TestMoveTriples(t *testing.T) {
+	triples := MoveTriples(defaultBoard.ValidCells)
+	if 2*len(triples) != len(defaultBoard.Moves) {
+		t.Errorf("%d lines for %d moves, want half as many lines", len(triples), len(defaultBoard.Moves))
+	}
+	for _, triple := range triples {
+		var rows, cols [3]int
+		for i, cell := range triple {
+			if !IsValidBit(cell) {
+				t.Errorf("line %v has the invalid cell %d", triple, cell)
+			}
+			rows[i], cols[i] = bitToCoord(cell)
+		}
+		sameRow := rows[0] == rows[1] && rows[1] == rows[2] && cols[1] == cols[0]+1 && cols[2] == cols[1]+1
+		sameCol := cols[0] == cols[1] && cols[1] == cols[2] && rows[1] == rows[0]+1 && rows[2] == rows[1]+1
+		if !sameRow && !sameCol {
+			t.Errorf("the cells of line %v are not consecutive in a row or column", triple)
+		}
+	}
+}
+
+// a search without symmetry pruning that uses up its budget starts over with it, and then
+// finds what a search with symmetry pruning finds
+func TestSymmetryFallback(t *testing.T) {
+	newSolver := func(symmetry bool, fallback uint64) *Solver {
+		solver := NewSolver()
+		solver.Rand = rand.New(rand.NewSource(benchmarkSeed))
+		solver.SetOrder("random")
+		solver.Symmetry = symmetry
+		solver.SymmetryFallback = fallback
+		if _, err := solver.Solve(context.Background(), INITIAL_BOARD, GOAL_BOARD); err != nil {
+			t.Fatal(err)
+		}
+		return solver
+	}
+	symmetric, plain := newSolver(true, 0), newSolver(false, 0)
+	// the budget is checked together with the context
+	restarted := newSolver(false, contextCheckInterval)
+	if !restarted.Stats.SymmetryRestart {
+		t.Fatalf("the search did not restart after %d boards", contextCheckInterval)
+	}
+	if !slices.Equal(restarted.Solution, symmetric.Solution) {
+		t.Errorf("the restarted search finds another solution than the one with symmetry pruning")
+	}
+	// the search stopped by the budget visits a few more boards while it returns
+	if before := restarted.NodesVisited() - symmetric.NodesVisited(); before < contextCheckInterval || before > 2*contextCheckInterval {
+		t.Errorf("the restarted search visited %d boards, want %d before and %d after the restart",
+			restarted.NodesVisited(), contextCheckInterval, symmetric.NodesVisited())
+	}
+	unused := newSolver(false, 2*plain.NodesVisited())
+	if unused.Stats.SymmetryRestart || !slices.Equal(unused.Solution, plain.Solution) {
+		t.Errorf("the search restarted though it is done before using up its budget")
+	}
+}
+
+// solvers of different variants run side by side: the European one solves a puzzle using a
+// corner of its board, which the English one rejects, while the English one solves its own
+func TestSolversOfDifferentVariants(t *testing.T) {
+	european := NewBoardSolver(Variants["european"].Board())
+	if err := european.SetOrder("center"); err != nil {
+		t.Fatal(err)
+	}
+	// b2 jumps over b3 into b4, which jumps over c4 into d4 (the cells are not parsed, since
+	// b2 is not on the board of the selected variant)
+	start, goal := SetCell(SetCell(SetCell(0, 1, 1), 2, 1), 3, 2), SetCell(0, 3, 3)
+	english, englishStart := NewSolver(), boardAfterMoves(t, 18)
+	done := make(chan error)
+	go func() {
+		_, err := english.Solve(context.Background(), englishStart, GOAL_BOARD)
+		done <- err
+	}()
+	if _, err := european.Solve(context.Background(), start, goal); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if want := SetCell(SetCell(0, 3, 1), 3, 2); len(european.Solution) != 3 || european.Solution[1] != want || european.Solution[2] != goal {
+		t.Errorf("got the European solution %v", european.Solution)
+	}
+	if err := VerifySolution(englishStart, GOAL_BOARD, english.Solution); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewSolver().Solve(context.Background(), start, goal); !errors.Is(err, ErrInvalidCell) {
+		t.Errorf("the English solver got %v for a cell outside of its board, want ErrInvalidCell", err)
+	}
+}
+
+func TestSolveCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	solver := NewSolver()
+	if _, err := solver.Solve(ctx, INITIAL_BOARD, GOAL_BOARD); err != context.Canceled {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+	if solver.Solution != nil {
+		t.Errorf("the canceled search has a solution")
+	}
+	// the context is only checked every contextCheckInterval boards, after that the search
+	// only returns
+	if nodes := solver.NodesVisited(); nodes > 2*contextCheckInterval {
+		t.Errorf("the canceled search visited %d boards", nodes)
+	}
+}
+
+// every step is the board before a move and the move, which is legal on it and leads to the
+// board of the next step
+func TestResultSteps(t *testing.T) {
+	solver := NewSolver()
+	result, err := solver.Solve(context.Background(), boardAfterMoves(t, 20), GOAL_BOARD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := result.Steps()
+	if len(steps) != result.Moves {
+		t.Fatalf("got %d steps for %d moves", len(steps), result.Moves)
+	}
+	for i, step := range steps {
+		if step.Board != result.Path[i] {
+			t.Errorf("step %d is not on board %d of the solution", i+1, i)
+		}
+		if next, ok := Apply(step.Board, step.Move); !ok || next != result.Path[i+1] {
+			t.Errorf("the move %s of step %d does not lead to the next board", MoveDescription(step.Move), i+1)
+		}
+	}
+	if steps := (Result{}).Steps(); len(steps) != 0 {
+		t.Errorf("got %d steps without a solution", len(steps))
+	}
+}
+
+// the flags of the command line program are not registered for the programs importing the
+// package, only in its own flag set
+func TestCommandLineFlags(t *testing.T) {
+	if flag.Lookup("final") != nil || commandLine.Lookup("final") == nil {
+		t.Error("-final is registered in the flag set of the program importing the package")
+	}
+}
+
+// -fixed and -region are rejected with the searches that do not know them
+func TestCheckConstraintFlags(t *testing.T) {
+	defer func(fixed, restricted string, all, parallelSet, astarSet bool) {
+		*fixedCells, *region, *printAll, *parallel, *astar = fixed, restricted, all, parallelSet, astarSet
+	}(*fixedCells, *region, *printAll, *parallel, *astar)
+	*printAll, *parallel, *astar = false, false, false
+	for _, constraint := range []struct{ fixed, region string }{{"c1", ""}, {"", "c3,d3,e3"}} {
+		*fixedCells, *region = constraint.fixed, constraint.region
+		if err := checkConstraintFlags(); err != nil {
+			t.Errorf("%+v alone: %v", constraint, err)
+		}
+		for _, search := range []*bool{printAll, parallel, astar} {
+			*search = true
+			if err := checkConstraintFlags(); err == nil {
+				t.Errorf("%+v is accepted with another search", constraint)
+			}
+			*search = false
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package solitaire
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// the output of -json-state is one JSON object with everything known about a solve:
+//
+//	{"start": {...}, "goal": {...}, "solved": true, "moves": 31, "nodes": 1586406,
+//	 "elapsedMs": 1074, "path": [{...}, ...], "notation": ["d2-d4", ...], "jumps": [{...}, ...]}
+//
+// every board is encoded as {"value": "0x70e7feffce1c", "grid": ["  XXX", ...]}, the raw
+// bitboard (bit 7*row+column, row 0 at the top) as a hexadecimal string, since JSON numbers
+// can not hold every uint64 exactly, together with its 7 lines in the text format (see
+// ParseBoard). Every jump is encoded with the names and the hole numbers (see holeNumber) of its
+// cells, e.g. {"from": "d2", "over": "d3", "to": "d4", "fromHole": 5, "overHole": 10, "toHole": 17}.
+// An unsolved board has no path, no notation and no jumps
+
+// a board as encoded in the JSON state
+type jsonBoard struct {
+	Value string   `json:"value"`
+	Grid  []string `json:"grid"`
+}
+
+// the complete result of a solve as written by -json-state
+type SolveState struct {
+	Start    jsonBoard   `json:"start"`
+	Goal     jsonBoard   `json:"goal"`
+	Solved   bool        `json:"solved"`
+	Moves    int         `json:"moves"`
+	Nodes    uint64      `json:"nodes"`
+	Elapsed  int64       `json:"elapsedMs"`
+	Path     []jsonBoard `json:"path,omitempty"`
+	Notation []string    `json:"notation,omitempty"`
+	Jumps    []jsonJump  `json:"jumps,omitempty"`
+}
+
+// a move as encoded in the JSON state
+type jsonJump struct {
+	From     string `json:"from"`
+	Over     string `json:"over"`
+	To       string `json:"to"`
+	FromHole int    `json:"fromHole"`
+	OverHole int    `json:"overHole"`
+	ToHole   int    `json:"toHole"`
+}
+
+// encode a board for the JSON state
+func newJSONBoard(board uint64) jsonBoard {
+	return jsonBoard{Value: fmt.Sprintf("%#x", board), Grid: boardLines(board)}
+}
+
+// get the JSON state of the result of solving the start board
+func NewSolveState(start uint64, goal uint64, result Result) SolveState {
+	state := SolveState{
+		Start:   newJSONBoard(start),
+		Goal:    newJSONBoard(goal),
+		Solved:  result.Solved,
+		Moves:   result.Moves,
+		Nodes:   result.NodesVisited,
+		Elapsed: result.Elapsed.Milliseconds(),
+	}
+	for _, board := range result.Path {
+		state.Path = append(state.Path, newJSONBoard(board))
+	}
+	for _, move := range SolutionMoves(result.Path) {
+		state.Notation = append(state.Notation, MoveString(move))
+		from, over, to := moveCells(move)
+		state.Jumps = append(state.Jumps, jsonJump{
+			From:     cellName(from),
+			Over:     cellName(over),
+			To:       cellName(to),
+			FromHole: defaultBoard.holeNumber(from),
+			OverHole: defaultBoard.holeNumber(over),
+			ToHole:   defaultBoard.holeNumber(to),
+		})
+	}
+	return state
+}
+
+// write the JSON state, indented and followed by a newline
+func WriteSolveState(w io.Writer, state SolveState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
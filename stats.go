@@ -0,0 +1,103 @@
+package solitaire
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// statistics about the search - the histogram is only collected if CollectStats
+// is set, since counting the applicable moves of every board costs extra run time
+type SearchStats struct {
+	// number of boards the search was called with
+	NodesVisited uint64
+	// maps the number of applicable (reverse) moves of a board to the
+	// number of visited boards that had that many applicable moves
+	BranchingFactor map[int]int
+	// set if the seen boards grew past Solver.SeenBoardsWarning
+	SeenBoardsExceeded bool
+	// the approximate memory used by the seen boards (see Solver.EstimatedMemory)
+	EstimatedMemory uint64
+	// set if the search restarted with symmetry pruning (see Solver.SymmetryFallback)
+	SymmetryRestart bool
+}
+
+// check the number of seen boards against SeenBoardsWarning, and warn once if it is exceeded
+// (this is checked every contextCheckInterval boards, so the warning can come a little late)
+func (s *Solver) checkSeenBoards() {
+	if s.SeenBoardsWarning <= 0 || s.Stats.SeenBoardsExceeded || len(s.seenBoards) <= s.SeenBoardsWarning {
+		return
+	}
+	s.Stats.SeenBoardsExceeded = true
+	if s.Warnings != nil {
+		fmt.Fprintf(s.Warnings, "warning: the search has seen more than %d boards, it may run out of memory (consider a timeout or a smaller board)\n",
+			s.SeenBoardsWarning)
+	}
+}
+
+// count the applicable moves of a visited board, among the moves of the search (which leave
+// out the moves rejected by FixedCells, ValidCells and the like)
+func (s *Solver) recordStats(board uint64) {
+	applicable := 0
+	for _, move := range s.moves {
+		if (move.before&board) == 0 && (move.after&board) != 0 {
+			applicable++
+		}
+	}
+	s.Stats.BranchingFactor[applicable]++
+}
+
+// print what a stopped search has done so far: the visited boards, the deepest depth it
+// reached (see MaxDepth), the number of seen boards and the elapsed time
+func PrintPartialStats(w io.Writer, s *Solver, elapsed time.Duration) {
+	fmt.Fprintf(w, "nodes visited: %d\n", s.NodesVisited())
+	fmt.Fprintf(w, "depth reached: %d\n", s.MaxDepth())
+	fmt.Fprintf(w, "seen boards: %d\n", s.seen.Load())
+	fmt.Fprintf(w, "elapsed: %v\n", elapsed.Round(time.Millisecond))
+}
+
+// print the collected search statistics
+func PrintStats(stats SearchStats) {
+	fmt.Printf("nodes visited: %d\n", stats.NodesVisited)
+	fmt.Printf("estimated memory of the seen boards: %.1f MB\n", float64(stats.EstimatedMemory)/1e6)
+	if stats.SymmetryRestart {
+		fmt.Println("restarted with symmetry pruning (the nodes include the first search)")
+	}
+	fmt.Println("applicable moves -> boards:")
+	counts := make([]int, 0, len(stats.BranchingFactor))
+	for count := range stats.BranchingFactor {
+		counts = append(counts, count)
+	}
+	sort.Ints(counts)
+	for _, count := range counts {
+		fmt.Printf("%4d -> %d\n", count, stats.BranchingFactor[count])
+	}
+}
+
+// add a visited board to the current path and remember the path if it is the deepest so far
+func (s *Solver) pushPath(board uint64) {
+	s.currentPath = append(s.currentPath, board)
+	if len(s.currentPath) > len(s.DeepestPath) {
+		// store the path in playing order, i.e. ending with the goal board
+		s.DeepestPath = s.DeepestPath[:0]
+		for i := len(s.currentPath) - 1; i >= 0; i-- {
+			s.DeepestPath = append(s.DeepestPath, s.currentPath[i])
+		}
+	}
+}
+
+// remove the last visited board from the current path
+func (s *Solver) popPath() {
+	s.currentPath = s.currentPath[:len(s.currentPath)-1]
+}
+
+// print the deepest partial path explored by a search
+func PrintDeepestPath(path []uint64) {
+	if len(path) == 0 {
+		fmt.Println("the goal was ruled out without searching (e.g. by the color invariant)")
+		return
+	}
+	fmt.Printf("deepest partial path (%d moves before the goal):\n", len(path)-1)
+	PrintSolution(path, boardsPerRow())
+}
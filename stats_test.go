@@ -0,0 +1,20 @@
+package solitaire
+
+import "testing"
+
+// the applicable moves are counted among the moves of the search, without the moves rejected
+// by the fixed cells
+func TestRecordStatsConstraints(t *testing.T) {
+	fixed, err := parseCells("d5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSolver()
+	s.Reset()
+	s.applyConstraints([]Constraint{FixedCellsConstraint(fixed)})
+	// 4 reverse moves lead to the single peg in d4, one of them jumps over d5
+	s.recordStats(GOAL_BOARD)
+	if got := s.Stats.BranchingFactor; len(got) != 1 || got[3] != 1 {
+		t.Errorf("got the branching factors %v, want 3 moves once", got)
+	}
+}
@@ -0,0 +1,158 @@
+package solitaire
+
+// the block strategy solves the standard puzzle of the English board without searching, by
+// composing packages: short sequences of moves clearing a block of pegs that leave the cells
+// around the block as they were, apart from the block itself. A package needs a catalyst, a
+// peg next to the block which jumps into it and is jumped back in the end, and a hole the
+// catalyst jumps into, so it can only be played if the cells around the block are right
+
+// a package of moves clearing a block of pegs, given by its moves at one placement as the
+// row and column of the jumping peg followed by those of its destination
+type blockPackage struct {
+	name  string
+	moves [][4]int
+}
+
+// the 3-purge clears a line of 3 pegs (the middle row of its placement) with a catalyst peg
+// next to the first peg of the line and a hole beyond it
+var threePurge = blockPackage{"3-purge", [][4]int{
+	{0, 1, 2, 1}, {1, 3, 1, 1}, {2, 1, 0, 1},
+}}
+
+// the 6-purge clears a block of 2 x 3 pegs (the lower two rows of its placement) with a
+// catalyst peg diagonally next to a corner of the block and two holes along its long side
+var sixPurge = blockPackage{"6-purge", [][4]int{
+	{2, 1, 0, 1}, {0, 0, 0, 2}, {1, 3, 1, 1}, {2, 3, 2, 1}, {2, 1, 0, 1}, {0, 2, 0, 0},
+}}
+
+// get the moves of all placements of the package on the board: its moves rotated/reflected
+// and shifted to every position where all of them are moves of the board
+func (p *blockPackage) placements() [][]Move {
+	var placements [][]Move
+	for t := Identity; t <= ReflectAntiDiagonal; t++ {
+		for rowShift := -6; rowShift <= 6; rowShift++ {
+			for colShift := -6; colShift <= 6; colShift++ {
+				var moves []Move
+				for _, m := range p.moves {
+					fromRow, fromCol := t.apply(m[0], m[1])
+					toRow, toCol := t.apply(m[2], m[3])
+					move, ok := boardMove(fromRow+rowShift, fromCol+colShift, toRow+rowShift, toCol+colShift)
+					if !ok {
+						break
+					}
+					moves = append(moves, move)
+				}
+				if len(moves) == len(p.moves) {
+					placements = append(placements, moves)
+				}
+			}
+		}
+	}
+	return placements
+}
+
+// get the move of the peg in a cell jumping into another cell, if it is a move of the board
+func boardMove(fromRow int, fromCol int, toRow int, toCol int) (Move, bool) {
+	if fromRow < 0 || fromRow > 6 || fromCol < 0 || fromCol > 6 || toRow < 0 || toRow > 6 || toCol < 0 || toCol > 6 {
+		return Move{}, false
+	}
+	from, to := coordToBit(fromRow, fromCol), coordToBit(toRow, toCol)
+	for _, move := range defaultBoard.Moves {
+		if moveFrom, _, moveTo := moveCells(move); moveFrom == from && moveTo == to {
+			return move, true
+		}
+	}
+	return Move{}, false
+}
+
+// play the package on the board to clear the cells, at the first placement clearing them
+// whose moves are legal (all of them leave the same board). Returns the boards after each
+// move, false if the package can not clear the cells on this board
+func (p *blockPackage) play(board uint64, cells uint64) ([]uint64, bool) {
+	for _, moves := range p.placements() {
+		var cleared uint64
+		for _, move := range moves {
+			cleared ^= move.all
+		}
+		if cleared != cells {
+			continue
+		}
+		path := make([]uint64, 0, len(moves))
+		next, ok := board, true
+		for _, move := range moves {
+			if next, ok = Apply(next, move); !ok {
+				break
+			}
+			path = append(path, next)
+		}
+		if ok {
+			return path, true
+		}
+	}
+	return nil, false
+}
+
+// a step of the block strategy: a package clearing the cells, or a single move in from-to
+// notation if there is no package
+type strategyStep struct {
+	pkg   *blockPackage
+	cells string
+}
+
+// the block strategy of the standard puzzle of the English board: two 3-purges open the
+// center, two 6-purges clear the upper and the left arm, and the lower and the right arm are
+// cleared by single moves and two more 3-purges
+var englishStrategy = []strategyStep{
+	{&threePurge, "d3,e3,f3"},
+	{&threePurge, "c3,c4,c5"},
+	{&sixPurge, "c1,d1,e1,c2,d2,e2"},
+	{&sixPurge, "a3,b3,a4,b4,a5,b5"},
+	{nil, "e5-c5"},
+	{nil, "d7-d5"},
+	{&threePurge, "c5,c6,c7"},
+	{nil, "g5-e5"},
+	{nil, "d5-f5"},
+	{nil, "e7-e5"},
+	{nil, "g3-g5"},
+	{&threePurge, "e5,f5,g5"},
+	{nil, "f4-d4"},
+}
+
+// get the solution of the standard puzzle of the English board played by the block strategy,
+// without searching. Returns false for all other start and goal boards and for the other
+// variants, which have to be searched
+func StrategySolution(start uint64, goal uint64) ([]uint64, bool) {
+	english := Variants["english"]
+	if defaultBoard.ValidCells != english.ValidCells || start != english.DefaultStart || goal != english.DefaultGoal {
+		return nil, false
+	}
+	path := []uint64{start}
+	for _, step := range englishStrategy {
+		board := path[len(path)-1]
+		if step.pkg == nil {
+			move, err := ParseMove(step.cells)
+			if err != nil {
+				return nil, false
+			}
+			next, ok := Apply(board, move)
+			if !ok {
+				return nil, false
+			}
+			path = append(path, next)
+			continue
+		}
+		cells, err := parseCells(step.cells)
+		if err != nil {
+			return nil, false
+		}
+		boards, ok := step.pkg.play(board, cells)
+		if !ok {
+			return nil, false
+		}
+		path = append(path, boards...)
+	}
+	if path[len(path)-1] != goal {
+		return nil, false
+	}
+	return path, true
+}
@@ -0,0 +1,70 @@
+package solitaire
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestStrategySolution(t *testing.T) {
+	path, ok := StrategySolution(INITIAL_BOARD, GOAL_BOARD)
+	if !ok {
+		t.Fatal("the block strategy does not solve the standard puzzle")
+	}
+	if err := VerifySolution(INITIAL_BOARD, GOAL_BOARD, path); err != nil {
+		t.Fatal(err)
+	}
+	// other boards are left to the search
+	if _, ok := StrategySolution(boardAfterMoves(t, 1), GOAL_BOARD); ok {
+		t.Error("the block strategy plays another start board")
+	}
+	if _, ok := StrategySolution(INITIAL_BOARD, 1<<coordToBit(0, 3)); ok {
+		t.Error("the block strategy plays another goal board")
+	}
+}
+
+// every placement of a package removes as many pegs as it has moves and only changes these
+// cells, which form a line of 3 for the 3-purge and a block of 2 x 3 for the 6-purge
+func TestPackagePlacements(t *testing.T) {
+	for _, pkg := range []*blockPackage{&threePurge, &sixPurge} {
+		placements := pkg.placements()
+		if len(placements) == 0 {
+			t.Errorf("%s has no placements", pkg.name)
+		}
+		for _, moves := range placements {
+			// the cells the moves need to be pegs (or holes) the first time they use them
+			pegs, used := uint64(0), uint64(0)
+			board := uint64(0)
+			for _, move := range moves {
+				for _, need := range []uint64{move.before, move.after} {
+					fresh := need &^ used
+					used |= fresh
+					if need == move.before {
+						pegs |= fresh
+						board |= fresh
+					}
+				}
+				next, ok := Apply(board, move)
+				if !ok {
+					t.Fatalf("%s placement %v has a move on a cell in the wrong state", pkg.name, moves)
+				}
+				board = next
+			}
+			cleared := pegs &^ board
+			if board&^pegs != 0 || bits.OnesCount64(cleared) != len(moves) {
+				t.Fatalf("%s placement %v clears %v and fills %v", pkg.name, moves, cellList(cleared), cellList(board&^pegs))
+			}
+			rows, cols := map[int]bool{}, map[int]bool{}
+			for _, cell := range cellList(cleared) {
+				c, err := parseCell(cell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				row, col := bitToCoord(c)
+				rows[row], cols[col] = true, true
+			}
+			if len(rows)*len(cols) != len(moves) {
+				t.Errorf("%s placement %v clears %v, not a block", pkg.name, moves, cellList(cleared))
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package solitaire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// get a random board: the full board with "remove" randomly chosen pegs removed
+func RandomBoard(remove int) uint64 {
+	board := FullBoard()
+	for remove > 0 && board != 0 {
+		cell := rand.Intn(49)
+		if board&(1<<cell) != 0 {
+			board &^= 1 << cell
+			remove--
+		}
+	}
+	return board
+}
+
+// solve n random puzzles, each for at most "timeout", and print how many of them were
+// solved and how long it took on average. With remove > 0 the puzzles are random boards
+// (see RandomBoard), which are not always solvable, and IsSolvable is compared with the
+// result of the search; otherwise they are generated by RandomPuzzle with the given number
+// of moves
+func RunRandomPuzzles(n int, remove int, moves int, timeout time.Duration) {
+	solver := NewSolver()
+	var solved, unsolvable, timedOut int
+	// puzzles rejected by IsSolvable, those that the search solved anyway and those that
+	// were not rejected but have no solution
+	var rejected, wrong, missed int
+	var total time.Duration
+	for i := 0; i < n; i++ {
+		start := RandomPuzzle(moves)
+		if remove > 0 {
+			start = RandomBoard(remove)
+		}
+		predicted := IsSolvable(start, GOAL_BOARD)
+		if !predicted {
+			rejected++
+		}
+		solver.SetOrder("random")
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		result, err := solver.Solve(ctx, start, GOAL_BOARD)
+		total += result.Elapsed
+		cancel()
+		switch {
+		case err == nil:
+			solved++
+			// the invariant can never reject a solvable board
+			if !predicted {
+				wrong++
+			}
+		case errors.Is(err, ErrNoSolution):
+			unsolvable++
+			if predicted {
+				missed++
+			}
+		default:
+			timedOut++
+		}
+	}
+	fmt.Printf("solved %d of %d puzzles (%d without solution, %d timed out)\n", solved, n, unsolvable, timedOut)
+	if n > 0 {
+		fmt.Printf("average search time: %v\n", (total / time.Duration(n)).Round(time.Microsecond))
+	}
+	if remove > 0 {
+		fmt.Printf("IsSolvable rejected %d puzzles, %d of the others had no solution\n", rejected, missed)
+		if wrong > 0 {
+			fmt.Printf("%d rejected puzzles were solved, this is a bug\n", wrong)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package solitaire
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// how the boards are drawn in the terminal
+type BoardStyle struct {
+	// the characters of a peg and of an empty cell
+	Peg  string
+	Hole string
+	// draws a box around the 7 x 7 grid
+	Border bool
+}
+
+// the board styles that can be selected by name, "ascii" works on every terminal
+var boardStyles = map[string]BoardStyle{
+	"ascii":   {Peg: "X", Hole: "0"},
+	"unicode": {Peg: "●", Hole: "○"},
+	"box":     {Peg: "●", Hole: "○", Border: true},
+}
+
+// the style used by PrintSolution and the other functions printing boards
+var boardStyle = boardStyles["ascii"]
+
+// select the style the boards are printed in by its name
+func SetBoardStyle(name string) error {
+	style, found := boardStyles[name]
+	if !found {
+		return fmt.Errorf("unknown board style %q (known styles: ascii, unicode, box)", name)
+	}
+	boardStyle = style
+	return nil
+}
+
+// the number of lines of a printed board
+func boardHeight() int {
+	if boardStyle.Border {
+		return 9
+	}
+	return 7
+}
+
+// the number of characters of a line of a printed board
+func boardWidth() int {
+	if boardStyle.Border {
+		return 9
+	}
+	return 7
+}
+
+// print one line of a board in the current style, with the border (if any) the first and
+// the last line are the border and the others the lines of the board (see printLine)
+func printStyledLine(board uint64, prev_board uint64, line int) {
+	if !boardStyle.Border {
+		defaultBoard.printLine(board, prev_board, line)
+		return
+	}
+	switch line {
+	case 0:
+		fmt.Print("┌" + strings.Repeat("─", 7) + "┐")
+	case 8:
+		fmt.Print("└" + strings.Repeat("─", 7) + "┘")
+	default:
+		fmt.Print("│")
+		defaultBoard.printLine(board, prev_board, line-1)
+		fmt.Print("│")
+	}
+}
+
+// get the lines of a board in the current style without colors (for files), like
+// boardLines but with the peg and hole characters of the style and its border
+func styledBoardLines(board uint64) []string {
+	lines := boardLines(board)
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, "X", boardStyle.Peg)
+		lines[i] = strings.ReplaceAll(line, "0", boardStyle.Hole)
+	}
+	if !boardStyle.Border {
+		return lines
+	}
+	bordered := []string{"┌" + strings.Repeat("─", 7) + "┐"}
+	for _, line := range lines {
+		// pad by the characters, the pegs and holes of a style may take several bytes
+		bordered = append(bordered, "│"+line+strings.Repeat(" ", 7-utf8.RuneCountInString(line))+"│")
+	}
+	return append(bordered, "└"+strings.Repeat("─", 7)+"┘")
+}
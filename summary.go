@@ -0,0 +1,67 @@
+package solitaire
+
+import (
+	"fmt"
+	"strings"
+)
+
+// get the indices of "frames" evenly spaced boards of a path of the given length, always
+// including the first and the last board (fewer if the path is shorter)
+func frameIndices(length int, frames int) []int {
+	if length == 0 {
+		return nil
+	}
+	if frames < 2 || length == 1 {
+		return []int{length - 1}
+	}
+	var indices []int
+	for i := 0; i < frames; i++ {
+		index := i * (length - 1) / (frames - 1)
+		if len(indices) == 0 || indices[len(indices)-1] != index {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// print a short summary of a solution: the start board, the last board and evenly spaced
+// boards in between side by side, each labelled with the number of moves made before it
+func PrintSummary(path []uint64, frames int) {
+	indices := frameIndices(len(path), frames)
+	width := max(boardWidth(), 8)
+	fmt.Println(strings.TrimRight(columns(frameLabels(indices), width), " "))
+	for line := 0; line < boardHeight(); line++ {
+		for i, index := range indices {
+			if i > 0 {
+				fmt.Print("   ")
+			}
+			printStyledLine(path[index], path[index], line)
+			fmt.Print(strings.Repeat(" ", width-boardWidth()))
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d moves, %d peg(s) remaining\n", len(path)-1, PegCount(path[len(path)-1]))
+}
+
+// get the labels of the boards of a summary by their index in the path: "start" for the start
+// board (which is only shown with at least 2 frames), else the number of moves made before it
+func frameLabels(indices []int) []string {
+	labels := make([]string, len(indices))
+	for i, index := range indices {
+		if index == 0 {
+			labels[i] = "start"
+		} else {
+			labels[i] = fmt.Sprintf("move %d", index)
+		}
+	}
+	return labels
+}
+
+// join texts into columns of the given width, separated by 3 spaces
+func columns(texts []string, width int) string {
+	padded := make([]string, len(texts))
+	for i, text := range texts {
+		padded[i] = fmt.Sprintf("%-*s", width, text)
+	}
+	return strings.Join(padded, "   ")
+}
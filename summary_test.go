@@ -0,0 +1,39 @@
+package solitaire
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFrameIndices(t *testing.T) {
+	cases := []struct {
+		length, frames int
+		want           []int
+	}{
+		{32, 5, []int{0, 7, 15, 23, 31}},
+		{32, 2, []int{0, 31}},
+		// a single frame shows the last board
+		{32, 1, []int{31}},
+		{32, 0, []int{31}},
+		// a short path has fewer boards than frames
+		{3, 5, []int{0, 1, 2}},
+		{1, 5, []int{0}},
+		{0, 5, nil},
+	}
+	for _, c := range cases {
+		if got := frameIndices(c.length, c.frames); !slices.Equal(got, c.want) {
+			t.Errorf("frameIndices(%d, %d) = %v, want %v", c.length, c.frames, got, c.want)
+		}
+	}
+}
+
+// the boards are labelled by their index in the path, so a single frame showing the last
+// board is not labelled as the start
+func TestFrameLabels(t *testing.T) {
+	if got, want := frameLabels(frameIndices(32, 3)), []string{"start", "move 15", "move 31"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := frameLabels(frameIndices(32, 1)), []string{"move 31"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,142 @@
+package solitaire
+
+// a sweep is a chain of consecutive jumps made by the same peg
+
+// get the length of the longest sweep of a path, i.e. the maximum number of consecutive
+// moves where every move continues with the peg the previous move ended with
+func SweepCount(path []uint64) int {
+	longest, run, last := 0, 0, -1
+	for _, move := range SolutionMoves(path) {
+		from, _, to := moveCells(move)
+		if from == last {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+		last = to
+	}
+	return longest
+}
+
+// what a search looks for
+type SearchObjective int
+
+const (
+	// the first solution found (default)
+	FirstSolution SearchObjective = iota
+	// the solution with the longest sweep (see SweepCount)
+	LongestSweep
+	// the solution with the highest score (see Solver.Scoring)
+	MaxScore
+	// a solution keeping the pegs close together (see PathSpread), found by a heuristic
+	MostClustered
+	// the solution in which the fewest distinct pegs move (see MovingPegCount)
+	PegGolf
+	// the solution with the fewest moves when multiple jumps count as one (see MoveCount)
+	FewestMoves
+)
+
+// find the solution with the longest sweep by a forward depth first search over all
+// solutions. The longest sweep that can still be made from a board only depends on the
+// board, the cell the previous move ended in and the length of the current sweep, so the
+// result is remembered for every such state
+// Note: this is much slower than looking for any solution and keeps all visited states in
+// memory, it is only feasible for boards with up to about 20 pegs
+func (s *Solver) searchLongestSweep(start uint64) {
+	w := sweepSearch{
+		s:        s,
+		goal:     s.goal.Load(),
+		goalPegs: PegCount(s.goal.Load()),
+		longest:  map[sweepState]int8{},
+	}
+	if w.walk(start, -1, 0) < 0 || s.err != nil {
+		return
+	}
+	// follow the moves leading to the longest sweep
+	s.Solution = []uint64{start}
+	board, last, run := start, -1, 0
+	for board != w.goal {
+		best := w.walk(board, last, run)
+		for _, move := range s.moves {
+			next, ok := Apply(board, move)
+			if !ok || !s.allows(board, move) || (next != w.goal && s.Prune != nil && s.Prune(next)) {
+				continue
+			}
+			from, _, to := moveCells(move)
+			if nextRun := w.continued(from, last, run); w.sweepAfter(next, to, nextRun, from != last, run) == best {
+				board, last, run = next, to, nextRun
+				break
+			}
+		}
+		s.Solution = append(s.Solution, board)
+	}
+}
+
+// a board during searchLongestSweep together with the cell the previous move ended in and
+// the length of the current sweep
+type sweepState struct {
+	board uint64
+	last  int8
+	run   int8
+}
+
+// state of searchLongestSweep
+type sweepSearch struct {
+	s        *Solver
+	goal     uint64
+	goalPegs int
+	// the longest sweep that can be made from a state (-1 if the goal can not be reached)
+	longest map[sweepState]int8
+}
+
+// get the length of the sweep after a move from the cell "from", given the cell the
+// previous move ended in and the length of its sweep
+func (w *sweepSearch) continued(from int, last int, run int) int {
+	if from == last {
+		return run + 1
+	}
+	return 1
+}
+
+// get the longest sweep of the solutions continuing with the board reached by a move: the
+// longest sweep from that board, or the sweep the move ended if it is longer
+func (w *sweepSearch) sweepAfter(next uint64, to int, nextRun int, ended bool, run int) int {
+	longest := w.walk(next, to, nextRun)
+	if ended && longest >= 0 {
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// get the longest sweep of the solutions reaching the goal from the board (including the
+// current sweep of length run which ended in the cell last), -1 if there is none
+func (w *sweepSearch) walk(board uint64, last int, run int) int {
+	state := sweepState{board, int8(last), int8(run)}
+	if longest, found := w.longest[state]; found {
+		return int(longest)
+	}
+	s := w.s
+	s.visit(board)
+	if s.nodes.Add(1)%contextCheckInterval == 0 && s.ctx.Err() != nil {
+		s.err = s.ctx.Err()
+	}
+	if s.err != nil {
+		return -1
+	}
+	longest := -1
+	if board == w.goal {
+		longest = run
+	} else if PegCount(board) > w.goalPegs {
+		for _, move := range s.moves {
+			next, ok := Apply(board, move)
+			if !ok || !s.allows(board, move) || (next != w.goal && s.Prune != nil && s.Prune(next)) {
+				continue
+			}
+			from, _, to := moveCells(move)
+			longest = max(longest, w.sweepAfter(next, to, w.continued(from, last, run), from != last, run))
+		}
+	}
+	w.longest[state] = int8(longest)
+	return longest
+}
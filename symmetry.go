@@ -0,0 +1,247 @@
+package solitaire
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// the board has the symmetry of a square: it can be rotated by multiples of
+// 90 degrees and reflected along four axes without changing its shape, so any
+// board (or solution) can be transformed into up to 7 other equivalent ones
+type Transform int
+
+const (
+	Identity            Transform = iota
+	Rotate90                      // rotate clockwise by 90 degrees
+	Rotate180                     // rotate by 180 degrees
+	Rotate270                     // rotate clockwise by 270 degrees
+	ReflectHorizontal             // mirror left to right
+	ReflectVertical               // mirror top to bottom
+	ReflectDiagonal               // mirror along the diagonal from the top left to the bottom right
+	ReflectAntiDiagonal           // mirror along the diagonal from the top right to the bottom left
+)
+
+// map a cell (row and column) to its position under the transform
+func (t Transform) apply(row int, col int) (int, int) {
+	switch t {
+	case Rotate90:
+		return col, 6 - row
+	case Rotate180:
+		return 6 - row, 6 - col
+	case Rotate270:
+		return 6 - col, row
+	case ReflectHorizontal:
+		return row, 6 - col
+	case ReflectVertical:
+		return 6 - row, col
+	case ReflectDiagonal:
+		return col, row
+	case ReflectAntiDiagonal:
+		return 6 - col, 6 - row
+	}
+	return row, col
+}
+
+// the cell every cell is moved to by each transform
+var transformedCells = func() (cells [8][49]uint8) {
+	for t := Identity; t <= ReflectAntiDiagonal; t++ {
+		for cell := 0; cell < 49; cell++ {
+			row, col := t.apply(bitToCoord(cell))
+			cells[t][cell] = uint8(coordToBit(row, col))
+		}
+	}
+	return cells
+}()
+
+// get the transform undoing a transform
+func (t Transform) inverse() Transform {
+	switch t {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	}
+	// the other transforms undo themselves
+	return t
+}
+
+// get the transforms (other than the identity) mapping the valid cells and the moves of the
+// board onto themselves, so every board plays like its transformed boards - all 7 for the
+// English and the European board, none for the triangles
+func (b *Board) symmetries() []Transform {
+	moves := map[Move]bool{}
+	for _, move := range b.Moves {
+		moves[move] = true
+	}
+	var symmetries []Transform
+	for t := Rotate90; t <= ReflectAntiDiagonal; t++ {
+		symmetric := TransformBoard(b.ValidCells, t) == b.ValidCells
+		for _, move := range b.Moves {
+			if !symmetric {
+				break
+			}
+			transformed := Move{after: TransformBoard(move.after, t), before: TransformBoard(move.before, t), all: TransformBoard(move.all, t)}
+			symmetric = moves[transformed]
+		}
+		if symmetric {
+			symmetries = append(symmetries, t)
+		}
+	}
+	return symmetries
+}
+
+// apply a rotation/reflection to a board
+func TransformBoard(board uint64, t Transform) uint64 {
+	var result uint64
+	// move every peg to its transformed cell
+	for board != 0 {
+		cell := bits.TrailingZeros64(board)
+		board &= board - 1 // clear the lowest peg
+		result |= 1 << transformedCells[t][cell]
+	}
+	return result
+}
+
+// get a board under all 8 transforms (indexed by Transform), starting with the board
+// itself, followed by its three rotations and its four reflections
+func Transforms(board uint64) [8]uint64 {
+	var result [8]uint64
+	for board != 0 {
+		cell := bits.TrailingZeros64(board)
+		board &= board - 1
+		for t := range result {
+			result[t] |= 1 << transformedCells[t][cell]
+		}
+	}
+	return result
+}
+
+// the 7 bits of a row mirrored (column c moved to column 6 - c) and spread into a column
+// (column c moved to row c of column 0), both indexed by the bits of the row
+var mirroredRows, rowColumns = func() (mirrored [128]uint64, columns [128]uint64) {
+	for row := 0; row < 128; row++ {
+		for col := 0; col < 7; col++ {
+			if row&(1<<col) != 0 {
+				mirrored[row] |= 1 << (6 - col)
+				columns[row] |= 1 << (7 * col)
+			}
+		}
+	}
+	return mirrored, columns
+}()
+
+// get the smallest of the 8 transforms of a board, which is the same for all boards that
+// are rotations/reflections of each other. This gives the same as the minimum of Transforms,
+// but moves whole rows instead of single pegs: the board and the board reflected along the
+// diagonal are each mirrored left to right, top to bottom and both ways
+func canonicalBoard(board uint64) uint64 {
+	var reflected uint64
+	for row := 0; row < 7; row++ {
+		reflected |= rowColumns[board>>(7*row)&127] << row
+	}
+	key := board
+	for _, b := range [2]uint64{board, reflected} {
+		var mirrored, flipped, rotated uint64
+		for row := 0; row < 7; row++ {
+			line := b >> (7 * row) & 127
+			mirrored |= mirroredRows[line] << (7 * row)
+			flipped |= line << (7 * (6 - row))
+			rotated |= mirroredRows[line] << (7 * (6 - row))
+		}
+		key = min(key, b, mirrored, flipped, rotated)
+	}
+	return key
+}
+
+// check if one board is a rotation/reflection of the other (or the same board)
+func AreSymmetric(a uint64, b uint64) bool {
+	for _, transformed := range Transforms(a) {
+		if transformed == b {
+			return true
+		}
+	}
+	return false
+}
+
+// apply a rotation/reflection to every board of a solution path
+// since the board shape is symmetric, the result is again a valid solution
+// (from the transformed start to the transformed goal)
+func TransformSolution(path []uint64, t Transform) []uint64 {
+	result := make([]uint64, len(path))
+	for i, board := range path {
+		result[i] = TransformBoard(board, t)
+	}
+	return result
+}
+
+// group solutions that are rotations/reflections of each other, returns the first solution
+// of every group (in the order of the given solutions) together with the size of the group
+func CollapseSymmetric(solutions [][]uint64) ([][]uint64, []int) {
+	var representatives [][]uint64
+	var sizes []int
+	// maps every transform of a representative to the representative's index
+	groups := map[string]int{}
+	for _, solution := range solutions {
+		if i, found := groups[pathKey(solution)]; found {
+			sizes[i]++
+			continue
+		}
+		for t := Identity; t <= ReflectAntiDiagonal; t++ {
+			key := pathKey(TransformSolution(solution, t))
+			if _, found := groups[key]; !found {
+				groups[key] = len(representatives)
+			}
+		}
+		representatives = append(representatives, solution)
+		sizes = append(sizes, 1)
+	}
+	return representatives, sizes
+}
+
+// a key identifying a path of boards, usable as map key
+func pathKey(path []uint64) string {
+	return fmt.Sprint(path)
+}
+
+// get the transforms (other than the identity) under which a search gives the same result
+// for a board and its transformed board: they have to map the board, the start and the goal
+// board, the fixed cells and the region of the solver onto themselves. Searches with
+// constraints or pruning are not known to be symmetric, so they have none
+func (s *Solver) searchSymmetries(start uint64, goal uint64) []Transform {
+	if s.Constraints != nil || s.Prune != nil {
+		return nil
+	}
+	var symmetries []Transform
+	for t := Rotate90; t <= ReflectAntiDiagonal; t++ {
+		symmetric := true
+		for _, cells := range []uint64{s.Board.ValidCells, start, goal, s.FixedCells, s.ValidCells} {
+			if TransformBoard(cells, t) != cells {
+				symmetric = false
+				break
+			}
+		}
+		if symmetric {
+			symmetries = append(symmetries, t)
+		}
+	}
+	return symmetries
+}
+
+// get the key of a board in the seen boards: with symmetry pruning the smallest of the board
+// and its transforms under the symmetries of the search, so all of them share one entry
+func (s *Solver) seenKey(board uint64) uint64 {
+	return symmetricKey(board, s.symmetries)
+}
+
+// get the smallest of a board and its transforms under the given symmetries (other than the
+// identity), see canonicalBoard
+func symmetricKey(board uint64, symmetries []Transform) uint64 {
+	if len(symmetries) == 7 {
+		return canonicalBoard(board)
+	}
+	key := board
+	for _, t := range symmetries {
+		key = min(key, TransformBoard(board, t))
+	}
+	return key
+}
@@ -0,0 +1,105 @@
+package solitaire
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestAreSymmetric(t *testing.T) {
+	board := boardAfterMoves(t, 5)
+	for transform := Identity; transform <= ReflectAntiDiagonal; transform++ {
+		if !AreSymmetric(board, TransformBoard(board, transform)) {
+			t.Errorf("a board is not symmetric to its transform %d", transform)
+		}
+	}
+	// two adjacent pegs are not symmetric to two pegs with a hole between them, nor is a
+	// board to another board after the same number of moves
+	a := uint64(1)<<coordToBit(0, 2) | 1<<coordToBit(0, 3)
+	b := uint64(1)<<coordToBit(0, 2) | 1<<coordToBit(0, 4)
+	if AreSymmetric(a, b) {
+		t.Errorf("c1 d1 and c1 e1 are symmetric")
+	}
+	path, err := ReplayNotation(INITIAL_BOARD, []string{"d2-d4", "d5-d3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if AreSymmetric(board, path[2]) || AreSymmetric(boardAfterMoves(t, 2), path[2]) {
+		t.Errorf("boards that are not transforms of each other are symmetric")
+	}
+}
+
+// four rotations by 90 degrees and every reflection applied twice give back the board, and
+// every transform is undone by its inverse
+func TestTransformBoard(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		board := random.Uint64() & defaultBoard.ValidCells
+		rotated := board
+		for j := 0; j < 4; j++ {
+			rotated = TransformBoard(rotated, Rotate90)
+		}
+		if rotated != board {
+			t.Errorf("%#x rotated four times is %#x", board, rotated)
+		}
+		for transform := ReflectHorizontal; transform <= ReflectAntiDiagonal; transform++ {
+			if twice := TransformBoard(TransformBoard(board, transform), transform); twice != board {
+				t.Errorf("%#x reflected twice by %d is %#x", board, transform, twice)
+			}
+		}
+		for transform := Identity; transform <= ReflectAntiDiagonal; transform++ {
+			if back := TransformBoard(TransformBoard(board, transform), transform.inverse()); back != board {
+				t.Errorf("%#x transformed by %d and back is %#x", board, transform, back)
+			}
+			if TransformBoard(board, transform)&^defaultBoard.ValidCells != 0 {
+				t.Errorf("%#x transformed by %d has pegs outside of the board", board, transform)
+			}
+		}
+	}
+}
+
+// canonicalBoard is the smallest of the transforms of a board, the same for all of them
+func TestCanonicalBoard(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		transforms := Transforms(random.Uint64() & defaultBoard.ValidCells)
+		want := slices.Min(transforms[:])
+		for transform, board := range transforms {
+			if board != TransformBoard(transforms[0], Transform(transform)) {
+				t.Errorf("Transforms(%#x)[%d] is not the transformed board", transforms[0], transform)
+			}
+			if got := canonicalBoard(board); got != want {
+				t.Errorf("canonicalBoard(%#x) = %#x, want %#x", board, got, want)
+			}
+		}
+	}
+}
+
+// a transformed solution is a solution of the transformed puzzle
+func TestTransformSolution(t *testing.T) {
+	path, err := ReplayNotation(INITIAL_BOARD, englishSolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for transform := Identity; transform <= ReflectAntiDiagonal; transform++ {
+		start, goal := TransformBoard(INITIAL_BOARD, transform), TransformBoard(GOAL_BOARD, transform)
+		if err := VerifySolution(start, goal, TransformSolution(path, transform)); err != nil {
+			t.Errorf("transform %d: %v", transform, err)
+		}
+	}
+}
+
+func BenchmarkCanonicalBoard(b *testing.B) {
+	board := boardAfterMoves(b, 10)
+	for i := 0; i < b.N; i++ {
+		coordSink += int(canonicalBoard(board) & 1)
+	}
+}
+
+func BenchmarkTransformsMin(b *testing.B) {
+	board := boardAfterMoves(b, 10)
+	for i := 0; i < b.N; i++ {
+		transforms := Transforms(board)
+		coordSink += int(slices.Min(transforms[:]) & 1)
+	}
+}
@@ -0,0 +1,99 @@
+package solitaire
+
+import "sync"
+
+// a transposition table that can be shared by several solvers (it is safe for concurrent
+// use), e.g. by a server solving many similar boards. It remembers start boards that can
+// not be solved and, for the boards of every solution found, the next board on the way to
+// the goal, so a later search for any of these boards is answered without searching.
+// Entries are keyed by board and goal, but not by the moves, so a table must only be
+// shared by solvers playing the same variant. A board and its goal that are rotations/
+// reflections of another board and goal share one entry (see key), the next boards are
+// stored in the orientation of the entry
+type TranspositionTable struct {
+	mu sync.RWMutex
+	// the next board on the way to the goal for solvable boards
+	next map[tableKey]uint64
+	// boards that can not be solved
+	unsolvable map[tableKey]bool
+	// the symmetries of the variant the table was created for
+	symmetries []Transform
+}
+
+// the key of a table entry
+type tableKey struct {
+	board, goal uint64
+}
+
+// create an empty transposition table for the current variant
+func NewTranspositionTable() *TranspositionTable {
+	return defaultBoard.NewTranspositionTable()
+}
+
+// create an empty transposition table for the solvers of the board
+func (b *Board) NewTranspositionTable() *TranspositionTable {
+	return &TranspositionTable{
+		next:       map[tableKey]uint64{},
+		unsolvable: map[tableKey]bool{},
+		symmetries: b.symmetries(),
+	}
+}
+
+// get the key of the entry of a board and its goal together with the transform leading to
+// it: of the transforms of both under the symmetries of the variant, the one with the
+// smallest goal and then the smallest board
+func (t *TranspositionTable) key(board uint64, goal uint64) (tableKey, Transform) {
+	key, transform := tableKey{board, goal}, Identity
+	for _, symmetry := range t.symmetries {
+		k := tableKey{TransformBoard(board, symmetry), TransformBoard(goal, symmetry)}
+		if k.goal < key.goal || (k.goal == key.goal && k.board < key.board) {
+			key, transform = k, symmetry
+		}
+	}
+	return key, transform
+}
+
+// look up a board: returns the solution path if the board is known to be solvable,
+// or nil and whether it is known to be unsolvable
+func (t *TranspositionTable) lookup(board uint64, goal uint64) ([]uint64, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	key, _ := t.key(board, goal)
+	if t.unsolvable[key] {
+		return nil, true
+	}
+	if _, found := t.next[key]; !found {
+		return nil, false
+	}
+	path := []uint64{board}
+	for board != goal {
+		key, transform := t.key(board, goal)
+		next, found := t.next[key]
+		if !found {
+			return nil, false
+		}
+		// the next board of the entry is transformed like the board
+		board = TransformBoard(next, transform.inverse())
+		path = append(path, board)
+	}
+	return path, false
+}
+
+// remember the boards of a solution path
+func (t *TranspositionTable) storeSolution(path []uint64) {
+	goal := path[len(path)-1]
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := 0; i < len(path)-1; i++ {
+		key, transform := t.key(path[i], goal)
+		t.next[key] = TransformBoard(path[i+1], transform)
+	}
+}
+
+// remember that a board can not be solved
+func (t *TranspositionTable) storeUnsolvable(board uint64, goal uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key, _ := t.key(board, goal)
+	t.unsolvable[key] = true
+}
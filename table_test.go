@@ -0,0 +1,69 @@
+package solitaire
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// a solution stored for a board answers the lookup of every rotation/reflection of it
+func TestTranspositionTableSymmetric(t *testing.T) {
+	table := NewTranspositionTable()
+	start := boardAfterMoves(t, 20)
+	solver := NewSolver()
+	if _, err := solver.Solve(context.Background(), start, GOAL_BOARD); err != nil {
+		t.Fatal(err)
+	}
+	table.storeSolution(solver.Solution)
+	for transform, board := range Transforms(start) {
+		goal := TransformBoard(GOAL_BOARD, Transform(transform))
+		path, unsolvable := table.lookup(board, goal)
+		if unsolvable || path == nil {
+			t.Errorf("no solution for the board under transform %d", transform)
+			continue
+		}
+		if err := VerifySolution(board, goal, path); err != nil {
+			t.Errorf("the solution under transform %d is wrong: %v", transform, err)
+		}
+	}
+	// a goal that is not a transform of the stored goal has no entry
+	if path, _ := table.lookup(start, 1<<coordToBit(0, 3)); path != nil {
+		t.Error("the solution is found for another goal")
+	}
+	table.storeUnsolvable(start, 1<<coordToBit(0, 3))
+	if _, unsolvable := table.lookup(TransformBoard(start, Rotate180), 1<<coordToBit(6, 3)); !unsolvable {
+		t.Error("the rotated board is not known to be unsolvable")
+	}
+}
+
+// solvers sharing a table from several goroutines, meant to be run with -race
+func TestTranspositionTableConcurrent(t *testing.T) {
+	table := NewTranspositionTable()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// every goroutine solves a rotation/reflection of the same boards, so they look
+			// up and store the same entries
+			for moves := 22; moves <= 28; moves++ {
+				path, err := ReplayNotation(INITIAL_BOARD, englishSolution[:moves])
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				start := TransformBoard(path[len(path)-1], Transform(i))
+				solver := NewSolver()
+				solver.Table = table
+				if _, err := solver.Solve(context.Background(), start, GOAL_BOARD); err != nil {
+					t.Error(err)
+					return
+				}
+				if err := VerifySolution(start, GOAL_BOARD, solver.Solution); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,104 @@
+package solitaire
+
+// check whether a win (reaching GOAL_BOARD) is guaranteed from a board with the given number
+// of undos left, whatever moves are made. A losing move (after which the goal can not be
+// reached any more) is noticed at once and can be taken back for one undo, then any other
+// move can be made. So a board is a guaranteed win if it is the goal, or if it has at most
+// as many losing moves as there are undos left and every other move (there has to be one)
+// leads to a guaranteed win with the undos that are left after trying all losing moves
+// Note: this decides for every board reachable from the board whether it can be solved, so
+// it is only feasible for boards with few pegs
+func GuaranteedWinnable(board uint64, undos int) bool {
+	return newWinCheck().guaranteed(board, max(undos, 0))
+}
+
+// get the fewest undos with which a win is guaranteed from a board (see GuaranteedWinnable),
+// returns false if the board can not be solved at all
+func MinUndos(board uint64) (int, bool) {
+	w := newWinCheck()
+	if !w.canSolve(board) {
+		return 0, false
+	}
+	// with as many undos as there can be losing moves on the way every solvable board is won
+	for undos := 0; ; undos++ {
+		if w.guaranteed(board, undos) {
+			return undos, true
+		}
+	}
+}
+
+// a board together with the undos left
+type winState struct {
+	board uint64
+	undos int
+}
+
+// state of GuaranteedWinnable
+type winCheck struct {
+	goal     uint64
+	goalPegs int
+	// whether the goal can be reached from a board, and whether a win is guaranteed
+	solvable map[uint64]bool
+	won      map[winState]bool
+}
+
+// get the state of a new check against GOAL_BOARD
+func newWinCheck() *winCheck {
+	return &winCheck{
+		goal:     GOAL_BOARD,
+		goalPegs: PegCount(GOAL_BOARD),
+		solvable: map[uint64]bool{},
+		won:      map[winState]bool{},
+	}
+}
+
+// check whether a win is guaranteed from the board with the undos left
+func (w *winCheck) guaranteed(board uint64, undos int) bool {
+	if board == w.goal {
+		return true
+	}
+	state := winState{board, undos}
+	if won, found := w.won[state]; found {
+		return won
+	}
+	var good []uint64
+	losing := 0
+	for _, move := range LegalMoves(board) {
+		next, _ := Apply(board, move)
+		if w.canSolve(next) {
+			good = append(good, next)
+		} else {
+			losing++
+		}
+	}
+	won := len(good) > 0 && losing <= undos
+	for _, next := range good {
+		if !won {
+			break
+		}
+		won = w.guaranteed(next, undos-losing)
+	}
+	w.won[state] = won
+	return won
+}
+
+// check whether the goal can be reached from the board
+func (w *winCheck) canSolve(board uint64) bool {
+	if board == w.goal {
+		return true
+	}
+	if solvable, found := w.solvable[board]; found {
+		return solvable
+	}
+	solvable := false
+	if PegCount(board) > w.goalPegs && IsSolvable(board, w.goal) {
+		for _, move := range LegalMoves(board) {
+			if next, _ := Apply(board, move); w.canSolve(next) {
+				solvable = true
+				break
+			}
+		}
+	}
+	w.solvable[board] = solvable
+	return solvable
+}
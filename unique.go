@@ -0,0 +1,79 @@
+package solitaire
+
+import (
+	"runtime"
+	"sync"
+)
+
+// check whether the start board has exactly one solution (leading to the goal board) up to
+// symmetry, i.e. whether all of its solutions are rotations/reflections of the first one
+// found - which is only possible for start and goal boards sharing the symmetry. The
+// enumeration stops at the first solution of another kind
+// Note: like CountSolutions, this is only feasible for boards with few pegs
+func HasUniqueSolution(start uint64, goal uint64) bool {
+	// the first solution under all transforms
+	var first map[string]bool
+	unique := EnumerateSolutions(start, goal, func(path []uint64) bool {
+		if first == nil {
+			first = map[string]bool{}
+			for t := Identity; t <= ReflectAntiDiagonal; t++ {
+				first[pathKey(TransformSolution(path, t))] = true
+			}
+			return true
+		}
+		return first[pathKey(path)]
+	})
+	return first != nil && unique
+}
+
+// find the candidate start boards with exactly one solution (leading to the goal board) up
+// to symmetry, see HasUniqueSolution. Of candidates that are rotations/reflections of each
+// other under a symmetry of the goal board only the first one is checked (under the other
+// transforms they lead to other goals), and at most maxCandidates boards are checked in all
+// (0 for no limit), by a pool of workers (one per CPU if workers is not positive). Returns
+// the boards found in the order of the candidates
+func UniqueSolutionBoards(candidates []uint64, goal uint64, maxCandidates int, workers int) []uint64 {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	var boards []uint64
+	symmetries := NewSolver().searchSymmetries(goal, goal)
+	seen := map[uint64]bool{}
+	for _, board := range candidates {
+		if maxCandidates > 0 && len(boards) == maxCandidates {
+			break
+		}
+		key := symmetricKey(board, symmetries)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		boards = append(boards, board)
+	}
+
+	unique := make([]bool, len(boards))
+	queue := make(chan int, len(boards))
+	for i := range boards {
+		queue <- i
+	}
+	close(queue)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				unique[i] = HasUniqueSolution(boards[i], goal)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var result []uint64
+	for i, board := range boards {
+		if unique[i] {
+			result = append(result, board)
+		}
+	}
+	return result
+}
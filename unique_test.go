@@ -0,0 +1,29 @@
+package solitaire
+
+import (
+	"slices"
+	"testing"
+)
+
+// with a goal off the center, a candidate is only dropped for a transform of a candidate
+// before that maps the goal onto itself: the reflection of a start across the middle row
+// leads to another goal
+func TestUniqueSolutionBoardsOffCenterGoal(t *testing.T) {
+	goal, err := parseCells("d1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// d3 jumps over d2 into d1, the reflection jumps into d7
+	toGoal, err := parseCells("d2,d3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reflected := TransformBoard(toGoal, ReflectVertical)
+	if reflected == toGoal {
+		t.Fatal("the reflection does not change the board")
+	}
+	got := UniqueSolutionBoards([]uint64{reflected, toGoal, toGoal}, goal, 0, 2)
+	if want := []uint64{toGoal}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
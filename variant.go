@@ -0,0 +1,171 @@
+package solitaire
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// a variant of the game: the shape of the board, the default puzzle and the possible moves
+// Note: all variants have to fit into the 7 x 7 layout of the board bitmap, which rules out
+// the larger standard boards (e.g. the 45-hole Wiegleb or the 41-hole diamond board)
+type Variant struct {
+	// valid cells of the board
+	ValidCells uint64
+	// the start and goal board of the default puzzle
+	DefaultStart uint64
+	DefaultGoal  uint64
+	// the lines of three cells a move can jump along, moves are created in both directions
+	MoveTriples [][3]int
+	// whether the lines wrap around the edges of the 7 x 7 layout (see TorusMoveTriples)
+	Torus bool
+	// whether there are lines along the diagonals from the top left to the bottom right (see
+	// TriangleMoveTriples)
+	Diagonal bool
+}
+
+// triangular boards are drawn skewed: row r holds the cells of columns 0 to r, so the
+// three directions of the lines of a triangle are the rows, the columns and the diagonals
+// from the top left to the bottom right
+
+// get the valid cells of a triangular board with the given number of rows (at most 7)
+func triangleCells(rows int) uint64 {
+	var cells uint64
+	for row := 0; row < rows; row++ {
+		for col := 0; col <= row; col++ {
+			cells |= 1 << coordToBit(row, col)
+		}
+	}
+	return cells
+}
+
+// get the lines of three valid cells of a triangular board: those of MoveTriples and those
+// along the diagonals from the top left to the bottom right
+func TriangleMoveTriples(validCells uint64) [][3]int {
+	triples := MoveTriples(validCells)
+	for cell := 0; cell < 33; cell++ {
+		if cell%7 <= 4 && validCells>>cell&1 != 0 && validCells>>(cell+8)&1 != 0 && validCells>>(cell+16)&1 != 0 {
+			triples = append(triples, [3]int{cell, cell + 8, cell + 16})
+		}
+	}
+	return triples
+}
+
+// get a variant on a torus: jumps may also wrap around from the last to the first column
+// of a row and from the last to the first row of a column, as long as all three cells are
+// valid - on the English board this only adds lines to the three long rows and columns
+func (v Variant) OnTorus() Variant {
+	v.MoveTriples = TorusMoveTriples(v.ValidCells)
+	v.Torus = true
+	return v
+}
+
+// get the lines of three valid cells of a board on a torus: the lines of MoveTriples and
+// those wrapping around the edges of the 7 x 7 layout, using the columns (or rows) 5, 6, 0
+// and 6, 0, 1
+func TorusMoveTriples(validCells uint64) [][3]int {
+	triples := MoveTriples(validCells)
+	valid := func(row int, col int) bool { return validCells&(1<<coordToBit(row%7, col%7)) != 0 }
+	for i := 0; i < 7; i++ {
+		for _, first := range []int{5, 6} {
+			if valid(i, first) && valid(i, first+1) && valid(i, first+2) {
+				triples = append(triples, [3]int{coordToBit(i, first), coordToBit(i, (first+1)%7), coordToBit(i, (first+2)%7)})
+			}
+			if valid(first, i) && valid(first+1, i) && valid(first+2, i) {
+				triples = append(triples, [3]int{coordToBit(first, i), coordToBit((first+1)%7, i), coordToBit((first+2)%7, i)})
+			}
+		}
+	}
+	return triples
+}
+
+// European (French) board: the English cross with the four additional cells between its arms
+var europeanCells, _ = strconv.ParseUint("0"+
+	"0011100"+
+	"0111110"+
+	"1111111"+
+	"1111111"+
+	"1111111"+
+	"0111110"+
+	"0011100", 2, 64)
+
+// all available variants by name
+var Variants = map[string]Variant{
+	"english": {
+		ValidCells:   englishCells,
+		DefaultStart: INITIAL_BOARD,
+		DefaultGoal:  GOAL_BOARD,
+		MoveTriples:  MoveTriples(englishCells),
+	},
+	// the center puzzle of the European board has no solution, so the default puzzle
+	// starts with slot a3 empty and ends with a single peg in a5
+	"european": {
+		ValidCells:   europeanCells,
+		DefaultStart: europeanCells &^ (1 << 14),
+		DefaultGoal:  1 << 28,
+		MoveTriples:  MoveTriples(europeanCells),
+	},
+	// the triangular boards with 15 and 21 holes, both starting with the top hole empty and
+	// ending with the last peg in it
+	"triangle15": {
+		ValidCells:   triangleCells(5),
+		DefaultStart: triangleCells(5) &^ 1,
+		DefaultGoal:  1,
+		MoveTriples:  TriangleMoveTriples(triangleCells(5)),
+		Diagonal:     true,
+	},
+	"triangle21": {
+		ValidCells:   triangleCells(6),
+		DefaultStart: triangleCells(6) &^ 1,
+		DefaultGoal:  1,
+		MoveTriples:  TriangleMoveTriples(triangleCells(6)),
+		Diagonal:     true,
+	},
+}
+
+// get the board of the variant: its valid cells and the moves along its lines
+func (v Variant) Board() *Board {
+	board := NewBoard(v.ValidCells, v.MoveTriples)
+	board.torus, board.diagonal = v.Torus, v.Diagonal
+	return board
+}
+
+// the board of the selected variant (see UseVariant), the functions without a Board of
+// their own use it
+var defaultBoard = Variants["english"].Board()
+
+// select the variant to play: this replaces the board (defaultBoard) and the default
+// puzzle (INITIAL_BOARD and GOAL_BOARD)
+func UseVariant(variant Variant) {
+	defaultBoard = variant.Board()
+	INITIAL_BOARD = variant.DefaultStart
+	GOAL_BOARD = variant.DefaultGoal
+}
+
+// standard boards that do not fit into the 7 x 7 layout of the board bitmap, with the reason
+var unsupportedVariants = map[string]string{
+	"wiegleb": "the 45-hole Wiegleb board needs a 9 x 9 layout, the board bitmap only holds 7 x 7 cells",
+}
+
+// get the variant with the given name, the error tells why a known board that does not fit
+// into the board bitmap is not available
+func lookupVariant(name string) (Variant, error) {
+	if variant, ok := Variants[name]; ok {
+		return variant, nil
+	}
+	if reason, ok := unsupportedVariants[name]; ok {
+		return Variant{}, fmt.Errorf("variant %q is not supported: %s", name, reason)
+	}
+	return Variant{}, fmt.Errorf("unknown variant %q, available variants: %s", name, variantNames())
+}
+
+// the names of all variants (sorted), separated by commas
+func variantNames() string {
+	names := make([]string, 0, len(Variants))
+	for name := range Variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
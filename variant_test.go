@@ -0,0 +1,20 @@
+package solitaire
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupVariant(t *testing.T) {
+	for name, want := range Variants {
+		if variant, err := lookupVariant(name); err != nil || variant.ValidCells != want.ValidCells {
+			t.Errorf("lookupVariant(%q) = %v", name, err)
+		}
+	}
+	if _, err := lookupVariant("wiegleb"); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("lookupVariant(\"wiegleb\") = %v, want an error telling it is not supported", err)
+	}
+	if _, err := lookupVariant("unknown"); err == nil {
+		t.Errorf("lookupVariant(\"unknown\") gives no error")
+	}
+}
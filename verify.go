@@ -0,0 +1,48 @@
+package solitaire
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// read the moves of a solution file in from-to notation (as printed by -moves): the moves
+// can be separated by new lines, spaces or commas, lines starting with "#" are ignored
+func readMoves(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var moves []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		moves = append(moves, strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || r == ','
+		})...)
+	}
+	return moves, nil
+}
+
+// check a solution file against a start board and print PASS or FAIL together with the
+// reason, e.g. the first illegal move - returns false if the solution is wrong
+func verifySolutionFile(solutionPath string, start uint64, goal uint64) bool {
+	moves, err := readMoves(solutionPath)
+	if err != nil {
+		fmt.Println("FAIL:", err)
+		return false
+	}
+	path, err := ReplayNotation(start, moves)
+	if err != nil {
+		fmt.Println("FAIL:", err)
+		return false
+	}
+	if err := VerifySolution(start, goal, path); err != nil {
+		fmt.Printf("FAIL: %v (%d peg(s) left after %d moves)\n", err, PegCount(path[len(path)-1]), len(moves))
+		return false
+	}
+	fmt.Printf("PASS: %d moves lead from the start to the goal board\n", len(moves))
+	return true
+}
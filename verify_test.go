@@ -0,0 +1,45 @@
+package solitaire
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// write the moves into a solution file in the format of -moves
+func writeMoves(t *testing.T, moves []string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "solution.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(moves, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerifySolutionFile(t *testing.T) {
+	if !verifySolutionFile(writeMoves(t, englishSolution), INITIAL_BOARD, GOAL_BOARD) {
+		t.Error("the known solution of the English board fails")
+	}
+	// the solution without its last move does not reach the goal
+	if verifySolutionFile(writeMoves(t, englishSolution[:len(englishSolution)-1]), INITIAL_BOARD, GOAL_BOARD) {
+		t.Error("an incomplete solution passes")
+	}
+}
+
+// a move from the jumped over cell into the destination (one cell instead of two) must not be
+// taken as the move jumping over that cell
+func TestVerifySolutionFileAdjacentSteps(t *testing.T) {
+	for i := range englishSolution {
+		moves := append([]string(nil), englishSolution...)
+		move, err := ParseMove(moves[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, over, to := moveCells(move)
+		moves[i] = cellName(over) + "-" + cellName(to)
+		if verifySolutionFile(writeMoves(t, moves), INITIAL_BOARD, GOAL_BOARD) {
+			t.Errorf("the solution with move %d replaced by the step %s passes", i+1, moves[i])
+		}
+	}
+}